@@ -0,0 +1,246 @@
+package go_arkham_oracle_sdk
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// AggregationStrategy selects how a set of per-source quotes is combined into a
+// single price for signing.
+type AggregationStrategy string
+
+const (
+	// StrategyMedian takes the median price across surviving samples.
+	StrategyMedian AggregationStrategy = "median"
+	// StrategyVWAP computes a volume-weighted mean across surviving samples.
+	StrategyVWAP AggregationStrategy = "vwap"
+	// StrategyTWAP computes a time-weighted average over the freshest in-window
+	// sample of each surviving source, weighting each by the time since the
+	// previous sample. Older samples in a source's ring buffer are not used.
+	StrategyTWAP AggregationStrategy = "twap"
+)
+
+// methodID identifies the aggregation strategy inside the signed message hash,
+// so downstream verifiers can enforce their own provenance rules.
+func (s AggregationStrategy) methodID() byte {
+	switch s {
+	case StrategyVWAP:
+		return 2
+	case StrategyTWAP:
+		return 3
+	default:
+		return 1 // median is the default method
+	}
+}
+
+// ErrInsufficientConfidence is returned when fewer than MinSources samples
+// survive outlier rejection for a token.
+var ErrInsufficientConfidence = fmt.Errorf("insufficient_confidence")
+
+// AggregatorOptions configures an Aggregator.
+type AggregatorOptions struct {
+	// Strategy selects how surviving samples are combined. Defaults to StrategyMedian.
+	Strategy AggregationStrategy
+	// Window bounds how far back in time a sample may be and still be considered
+	// "fresh" for aggregation. Defaults to 1 minute.
+	Window time.Duration
+	// MaxDeviationBps discards any sample whose deviation from the median exceeds
+	// this many basis points. Zero disables outlier rejection.
+	MaxDeviationBps uint32
+	// MinSources is the minimum number of surviving samples required before a
+	// price can be signed. Defaults to 1.
+	MinSources int
+	// RingSize bounds how many samples per token are retained per source.
+	// Defaults to 32.
+	RingSize int
+}
+
+// Aggregator maintains a rolling window of quotes per token, keyed by source,
+// and combines them according to the configured AggregationStrategy.
+type Aggregator struct {
+	mu      sync.Mutex
+	opts    AggregatorOptions
+	buffers map[string]map[string][]Quote // token -> source -> ring buffer of quotes
+}
+
+// NewAggregator creates an Aggregator with sane defaults applied to any zero
+// fields in opts.
+func NewAggregator(opts AggregatorOptions) *Aggregator {
+	if opts.Strategy == "" {
+		opts.Strategy = StrategyMedian
+	}
+	if opts.Window <= 0 {
+		opts.Window = time.Minute
+	}
+	if opts.MinSources <= 0 {
+		opts.MinSources = 1
+	}
+	if opts.RingSize <= 0 {
+		opts.RingSize = 32
+	}
+	return &Aggregator{
+		opts:    opts,
+		buffers: make(map[string]map[string][]Quote),
+	}
+}
+
+// Ingest records a new quote, appending it to the token+source ring buffer and
+// evicting the oldest entry once RingSize is exceeded.
+func (a *Aggregator) Ingest(q Quote) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	perSource, ok := a.buffers[q.Token]
+	if !ok {
+		perSource = make(map[string][]Quote)
+		a.buffers[q.Token] = perSource
+	}
+
+	ring := append(perSource[q.Source], q)
+	if len(ring) > a.opts.RingSize {
+		ring = ring[len(ring)-a.opts.RingSize:]
+	}
+	perSource[q.Source] = ring
+}
+
+// Aggregate combines the freshest quote per source for token into a single
+// price, rejecting outliers beyond MaxDeviationBps and requiring at least
+// MinSources surviving samples. It returns ErrInsufficientConfidence if the
+// requirement is not met.
+func (a *Aggregator) Aggregate(token string) (price float64, sourceCount int, err error) {
+	a.mu.Lock()
+	perSource := a.buffers[token]
+	samples := make([]Quote, 0, len(perSource))
+	cutoff := time.Now().Add(-a.opts.Window)
+	for _, ring := range perSource {
+		latest := freshestAfter(ring, cutoff)
+		if latest != nil {
+			samples = append(samples, *latest)
+		}
+	}
+	a.mu.Unlock()
+
+	if len(samples) == 0 {
+		return 0, 0, ErrInsufficientConfidence
+	}
+
+	survivors := samples
+	if a.opts.MaxDeviationBps > 0 && len(samples) > 1 {
+		med := medianPrice(samples)
+		survivors = rejectOutliers(samples, med, a.opts.MaxDeviationBps)
+	}
+
+	if len(survivors) < a.opts.MinSources {
+		return 0, len(survivors), ErrInsufficientConfidence
+	}
+
+	switch a.opts.Strategy {
+	case StrategyVWAP:
+		price = vwap(survivors)
+	case StrategyTWAP:
+		price = twap(survivors)
+	default:
+		price = medianPrice(survivors)
+	}
+
+	return price, len(survivors), nil
+}
+
+// Strategy returns the aggregation strategy this Aggregator was configured with.
+func (a *Aggregator) Strategy() AggregationStrategy {
+	return a.opts.Strategy
+}
+
+func freshestAfter(ring []Quote, cutoff time.Time) *Quote {
+	var best *Quote
+	for i := range ring {
+		q := ring[i]
+		if q.Timestamp.Before(cutoff) {
+			continue
+		}
+		if best == nil || q.Timestamp.After(best.Timestamp) {
+			best = &ring[i]
+		}
+	}
+	return best
+}
+
+func medianPrice(samples []Quote) float64 {
+	prices := make([]float64, len(samples))
+	for i, s := range samples {
+		prices[i] = s.Price
+	}
+	sort.Float64s(prices)
+	mid := len(prices) / 2
+	if len(prices)%2 == 0 {
+		return (prices[mid-1] + prices[mid]) / 2
+	}
+	return prices[mid]
+}
+
+func rejectOutliers(samples []Quote, median float64, maxDeviationBps uint32) []Quote {
+	survivors := make([]Quote, 0, len(samples))
+	for _, s := range samples {
+		if deviationBps(s.Price, median) <= uint64(maxDeviationBps) {
+			survivors = append(survivors, s)
+		}
+	}
+	return survivors
+}
+
+func deviationBps(price, median float64) uint64 {
+	if median == 0 {
+		return 0
+	}
+	diff := price - median
+	if diff < 0 {
+		diff = -diff
+	}
+	return uint64((diff / median) * 10_000)
+}
+
+func vwap(samples []Quote) float64 {
+	var weightedSum, totalVolume float64
+	for _, s := range samples {
+		volume := s.Volume
+		if volume == 0 {
+			volume = 1 // treat sources without volume data as equal weight
+		}
+		weightedSum += s.Price * volume
+		totalVolume += volume
+	}
+	if totalVolume == 0 {
+		return medianPrice(samples)
+	}
+	return weightedSum / totalVolume
+}
+
+func twap(samples []Quote) float64 {
+	if len(samples) == 1 {
+		return samples[0].Price
+	}
+
+	sorted := append([]Quote(nil), samples...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i].Timestamp.Before(sorted[j].Timestamp) })
+
+	var weightedSum, totalWeight float64
+	for i, s := range sorted {
+		var weight float64
+		if i == 0 {
+			weight = sorted[1].Timestamp.Sub(s.Timestamp).Seconds()
+		} else {
+			weight = s.Timestamp.Sub(sorted[i-1].Timestamp).Seconds()
+		}
+		if weight <= 0 {
+			weight = 1
+		}
+		weightedSum += s.Price * weight
+		totalWeight += weight
+	}
+	if totalWeight == 0 {
+		return medianPrice(samples)
+	}
+	return weightedSum / totalWeight
+}
@@ -0,0 +1,85 @@
+package go_arkham_oracle_sdk
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func quoteAt(source string, price float64, t time.Time) Quote {
+	return Quote{Source: source, Token: "ETH", Price: price, Timestamp: t}
+}
+
+func TestAggregatorMedianStrategy(t *testing.T) {
+	a := NewAggregator(AggregatorOptions{Strategy: StrategyMedian})
+	now := time.Now()
+	a.Ingest(quoteAt("a", 100, now))
+	a.Ingest(quoteAt("b", 110, now))
+	a.Ingest(quoteAt("c", 105, now))
+
+	price, count, err := a.Aggregate("ETH")
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if count != 3 {
+		t.Fatalf("sourceCount = %d, want 3", count)
+	}
+	if price != 105 {
+		t.Fatalf("median price = %v, want 105", price)
+	}
+}
+
+func TestAggregatorVWAPStrategy(t *testing.T) {
+	a := NewAggregator(AggregatorOptions{Strategy: StrategyVWAP})
+	now := time.Now()
+	a.Ingest(Quote{Source: "a", Token: "ETH", Price: 100, Volume: 1, Timestamp: now})
+	a.Ingest(Quote{Source: "b", Token: "ETH", Price: 200, Volume: 3, Timestamp: now})
+
+	price, _, err := a.Aggregate("ETH")
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	want := (100*1 + 200*3) / 4.0
+	if price != want {
+		t.Fatalf("vwap price = %v, want %v", price, want)
+	}
+}
+
+func TestAggregatorOutlierRejection(t *testing.T) {
+	a := NewAggregator(AggregatorOptions{Strategy: StrategyMedian, MaxDeviationBps: 100, MinSources: 1})
+	now := time.Now()
+	a.Ingest(quoteAt("a", 100, now))
+	a.Ingest(quoteAt("b", 101, now))
+	a.Ingest(quoteAt("c", 1000, now)) // wildly off, should be rejected
+
+	price, count, err := a.Aggregate("ETH")
+	if err != nil {
+		t.Fatalf("Aggregate: %v", err)
+	}
+	if count != 2 {
+		t.Fatalf("sourceCount = %d, want 2 (outlier rejected)", count)
+	}
+	if price == 1000 {
+		t.Fatalf("outlier 1000 should have been rejected from the result")
+	}
+}
+
+func TestAggregatorInsufficientConfidence(t *testing.T) {
+	a := NewAggregator(AggregatorOptions{MinSources: 2})
+	a.Ingest(quoteAt("a", 100, time.Now()))
+
+	_, _, err := a.Aggregate("ETH")
+	if !errors.Is(err, ErrInsufficientConfidence) {
+		t.Fatalf("Aggregate() err = %v, want ErrInsufficientConfidence", err)
+	}
+}
+
+func TestAggregatorIgnoresStaleSamples(t *testing.T) {
+	a := NewAggregator(AggregatorOptions{Window: time.Minute})
+	a.Ingest(quoteAt("a", 100, time.Now().Add(-2*time.Minute)))
+
+	_, _, err := a.Aggregate("ETH")
+	if !errors.Is(err, ErrInsufficientConfidence) {
+		t.Fatalf("Aggregate() err = %v, want ErrInsufficientConfidence for a stale-only sample set", err)
+	}
+}
@@ -0,0 +1,210 @@
+package go_arkham_oracle_sdk
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// AuthPolicy decides whether an incoming request to the oracle handler is
+// authorized. It supersedes the original TrustedClientKeys query-string
+// scheme, which leaked bearer tokens into proxy logs and allowed replay.
+type AuthPolicy interface {
+	Authorize(r *http.Request) AuthResult
+}
+
+// AuthResult is the outcome of an AuthPolicy check.
+type AuthResult struct {
+	Allowed bool
+	// Reason is a short machine-readable explanation, e.g. "unauthorized",
+	// "timestamp_out_of_window", "replay", "rate_limited". Empty when Allowed.
+	Reason string
+	// RetryAfter is set when Reason is "rate_limited", indicating how long
+	// the client should wait before retrying.
+	RetryAfter time.Duration
+}
+
+// ReplayCache tracks nonces that have already been seen within a window, so
+// a captured request can't be replayed.
+type ReplayCache interface {
+	// SeenRecently records (keyID, nonce) and reports whether it was already
+	// recorded within the last window.
+	SeenRecently(keyID, nonce string, window time.Duration) bool
+}
+
+// InMemoryReplayCache is a ReplayCache backed by an in-process map. Entries
+// older than the window they were checked against are opportunistically
+// purged on each call, so memory use stays bounded under normal traffic.
+type InMemoryReplayCache struct {
+	mu   sync.Mutex
+	seen map[string]time.Time
+}
+
+// NewInMemoryReplayCache creates an empty InMemoryReplayCache.
+func NewInMemoryReplayCache() *InMemoryReplayCache {
+	return &InMemoryReplayCache{seen: make(map[string]time.Time)}
+}
+
+func (c *InMemoryReplayCache) SeenRecently(keyID, nonce string, window time.Duration) bool {
+	key := keyID + ":" + nonce
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for k, t := range c.seen {
+		if now.Sub(t) > window {
+			delete(c.seen, k)
+		}
+	}
+
+	if t, ok := c.seen[key]; ok && now.Sub(t) <= window {
+		return true
+	}
+	c.seen[key] = now
+	return false
+}
+
+// RedisClient abstracts the subset of a Redis client ReplayCache needs, so
+// this package does not depend on a specific Redis library.
+type RedisClient interface {
+	// SetNX sets key to value with the given TTL only if key does not already
+	// exist, returning whether the set happened.
+	SetNX(ctx context.Context, key, value string, ttl time.Duration) (bool, error)
+}
+
+// RedisReplayCache is a ReplayCache backed by Redis, for deployments running
+// more than one oracle instance behind a load balancer.
+type RedisReplayCache struct {
+	Client RedisClient
+}
+
+func (c *RedisReplayCache) SeenRecently(keyID, nonce string, window time.Duration) bool {
+	didSet, err := c.Client.SetNX(context.Background(), "arkham:nonce:"+keyID+":"+nonce, "1", window)
+	if err != nil {
+		// Fail closed would block legitimate traffic on a Redis blip; fail
+		// open and let the HMAC/timestamp checks still gate the request.
+		return false
+	}
+	return !didSet
+}
+
+// HMACAuthPolicy authorizes requests signed as Client.attachAuthHeaders signs them:
+// clients send X-Arkham-KeyID, X-Arkham-Timestamp, X-Arkham-Nonce, and
+// X-Arkham-Signature = HMAC-SHA256(secret, keyID||timestamp||nonce||method||path||sortedQuery).
+type HMACAuthPolicy struct {
+	// Secrets maps keyID to its shared secret.
+	Secrets map[string]string
+	// Window bounds how far a request's timestamp may drift from now.
+	// Defaults to 60 seconds.
+	Window time.Duration
+	// ReplayCache tracks seen nonces. Defaults to an InMemoryReplayCache.
+	ReplayCache ReplayCache
+	// RPS and Burst configure a per-key token-bucket rate limiter. RPS <= 0
+	// disables rate limiting.
+	RPS   float64
+	Burst int
+
+	limiters sync.Map // keyID -> *rate.Limiter
+	once     sync.Once
+}
+
+func (p *HMACAuthPolicy) window() time.Duration {
+	if p.Window <= 0 {
+		return 60 * time.Second
+	}
+	return p.Window
+}
+
+func (p *HMACAuthPolicy) replayCache() ReplayCache {
+	p.once.Do(func() {
+		if p.ReplayCache == nil {
+			p.ReplayCache = NewInMemoryReplayCache()
+		}
+	})
+	return p.ReplayCache
+}
+
+func (p *HMACAuthPolicy) burst() int {
+	if p.Burst > 0 {
+		return p.Burst
+	}
+	return 1
+}
+
+func (p *HMACAuthPolicy) limiterFor(keyID string) *rate.Limiter {
+	if p.RPS <= 0 {
+		return nil
+	}
+	if v, ok := p.limiters.Load(keyID); ok {
+		return v.(*rate.Limiter)
+	}
+	limiter := rate.NewLimiter(rate.Limit(p.RPS), p.burst())
+	actual, _ := p.limiters.LoadOrStore(keyID, limiter)
+	return actual.(*rate.Limiter)
+}
+
+func (p *HMACAuthPolicy) Authorize(r *http.Request) AuthResult {
+	keyID := r.Header.Get("X-Arkham-KeyID")
+	timestamp := r.Header.Get("X-Arkham-Timestamp")
+	nonce := r.Header.Get("X-Arkham-Nonce")
+	signature := r.Header.Get("X-Arkham-Signature")
+	if keyID == "" || timestamp == "" || nonce == "" || signature == "" {
+		return AuthResult{Allowed: false, Reason: "unauthorized"}
+	}
+
+	secret, ok := p.Secrets[keyID]
+	if !ok {
+		return AuthResult{Allowed: false, Reason: "unauthorized"}
+	}
+
+	ts, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return AuthResult{Allowed: false, Reason: "unauthorized"}
+	}
+	if age := time.Since(time.Unix(ts, 0)); age > p.window() || age < -p.window() {
+		return AuthResult{Allowed: false, Reason: "timestamp_out_of_window"}
+	}
+
+	expected := hmacSignature(secret, keyID, timestamp, nonce, r.Method, r.URL.Path, r.URL.Query().Encode())
+	if !hmac.Equal([]byte(expected), []byte(signature)) {
+		return AuthResult{Allowed: false, Reason: "unauthorized"}
+	}
+
+	// Only consult/record the nonce once the signature is known-valid, so an
+	// attacker who doesn't know the secret can't pre-burn a nonce (or churn
+	// the cache) using just a (keyID, timestamp, nonce) triple lifted off a
+	// proxy log.
+	if p.replayCache().SeenRecently(keyID, nonce, p.window()) {
+		return AuthResult{Allowed: false, Reason: "replay"}
+	}
+
+	if limiter := p.limiterFor(keyID); limiter != nil && !limiter.Allow() {
+		return AuthResult{
+			Allowed:    false,
+			Reason:     "rate_limited",
+			RetryAfter: time.Duration(float64(time.Second) / p.RPS),
+		}
+	}
+
+	return AuthResult{Allowed: true}
+}
+
+// hmacSignature computes hex(HMAC-SHA256(secret, keyID||timestamp||nonce||method||path||sortedQuery)).
+func hmacSignature(secret, keyID, timestamp, nonce, method, path, sortedQuery string) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(keyID))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte(nonce))
+	mac.Write([]byte(method))
+	mac.Write([]byte(path))
+	mac.Write([]byte(sortedQuery))
+	return hex.EncodeToString(mac.Sum(nil))
+}
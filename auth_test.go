@@ -0,0 +1,104 @@
+package go_arkham_oracle_sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+)
+
+func signedRequest(t *testing.T, secret, keyID string, ts time.Time, nonce string) *http.Request {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodGet, "/price?token=ETH", nil)
+	timestamp := strconv.FormatInt(ts.Unix(), 10)
+	sig := hmacSignature(secret, keyID, timestamp, nonce, r.Method, r.URL.Path, r.URL.Query().Encode())
+	r.Header.Set("X-Arkham-KeyID", keyID)
+	r.Header.Set("X-Arkham-Timestamp", timestamp)
+	r.Header.Set("X-Arkham-Nonce", nonce)
+	r.Header.Set("X-Arkham-Signature", sig)
+	return r
+}
+
+func TestHMACAuthPolicyAuthorizeValid(t *testing.T) {
+	p := &HMACAuthPolicy{Secrets: map[string]string{"key1": "s3cret"}}
+	r := signedRequest(t, "s3cret", "key1", time.Now(), "nonce-1")
+	if result := p.Authorize(r); !result.Allowed {
+		t.Fatalf("Authorize() = %+v, want Allowed", result)
+	}
+}
+
+func TestHMACAuthPolicyRejectsTimestampOutOfWindow(t *testing.T) {
+	p := &HMACAuthPolicy{Secrets: map[string]string{"key1": "s3cret"}, Window: 60 * time.Second}
+	r := signedRequest(t, "s3cret", "key1", time.Now().Add(-5*time.Minute), "nonce-1")
+	result := p.Authorize(r)
+	if result.Allowed || result.Reason != "timestamp_out_of_window" {
+		t.Fatalf("Authorize() = %+v, want timestamp_out_of_window", result)
+	}
+}
+
+func TestHMACAuthPolicyRejectsReplay(t *testing.T) {
+	p := &HMACAuthPolicy{Secrets: map[string]string{"key1": "s3cret"}}
+	ts := time.Now()
+	first := signedRequest(t, "s3cret", "key1", ts, "nonce-1")
+	if result := p.Authorize(first); !result.Allowed {
+		t.Fatalf("first Authorize() = %+v, want Allowed", result)
+	}
+
+	second := signedRequest(t, "s3cret", "key1", ts, "nonce-1")
+	result := p.Authorize(second)
+	if result.Allowed || result.Reason != "replay" {
+		t.Fatalf("replayed Authorize() = %+v, want replay", result)
+	}
+}
+
+// TestHMACAuthPolicyInvalidSignatureDoesNotBurnNonce asserts that a request
+// with a known keyID and in-window timestamp but a forged signature never
+// reaches the replay cache, so it can't be used to pre-burn a nonce ahead of
+// the legitimate signed request (the attack the verify-before-replay-check
+// ordering fix addresses).
+func TestHMACAuthPolicyInvalidSignatureDoesNotBurnNonce(t *testing.T) {
+	p := &HMACAuthPolicy{Secrets: map[string]string{"key1": "s3cret"}}
+	ts := time.Now()
+
+	forged := signedRequest(t, "wrong-secret", "key1", ts, "nonce-1")
+	if result := p.Authorize(forged); result.Allowed || result.Reason != "unauthorized" {
+		t.Fatalf("forged Authorize() = %+v, want unauthorized", result)
+	}
+
+	legit := signedRequest(t, "s3cret", "key1", ts, "nonce-1")
+	if result := p.Authorize(legit); !result.Allowed {
+		t.Fatalf("legitimate Authorize() = %+v, want Allowed (nonce must not have been burned by the forged request)", result)
+	}
+}
+
+func TestHMACAuthPolicyRejectsRateLimited(t *testing.T) {
+	p := &HMACAuthPolicy{
+		Secrets: map[string]string{"key1": "s3cret"},
+		RPS:     1,
+		Burst:   1,
+	}
+	ts := time.Now()
+	first := signedRequest(t, "s3cret", "key1", ts, "nonce-1")
+	if result := p.Authorize(first); !result.Allowed {
+		t.Fatalf("first Authorize() = %+v, want Allowed", result)
+	}
+
+	second := signedRequest(t, "s3cret", "key1", ts, "nonce-2")
+	result := p.Authorize(second)
+	if result.Allowed || result.Reason != "rate_limited" {
+		t.Fatalf("second Authorize() = %+v, want rate_limited", result)
+	}
+	if result.RetryAfter <= 0 {
+		t.Fatalf("RetryAfter = %v, want > 0", result.RetryAfter)
+	}
+}
+
+func TestHMACAuthPolicyRejectsMissingHeaders(t *testing.T) {
+	p := &HMACAuthPolicy{Secrets: map[string]string{"key1": "s3cret"}}
+	r := httptest.NewRequest(http.MethodGet, "/price?token=ETH", nil)
+	result := p.Authorize(r)
+	if result.Allowed || result.Reason != "unauthorized" {
+		t.Fatalf("Authorize() = %+v, want unauthorized", result)
+	}
+}
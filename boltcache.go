@@ -0,0 +1,114 @@
+package go_arkham_oracle_sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.etcd.io/bbolt"
+)
+
+var (
+	boltEntriesBucket  = []byte("entries")
+	boltNegativeBucket = []byte("negative")
+)
+
+// BoltCache is a PriceCache backed by a BoltDB file, for deployments that
+// want the cache to survive process restarts.
+type BoltCache struct {
+	db *bbolt.DB
+}
+
+// OpenBoltCache opens (creating if necessary) a BoltDB-backed PriceCache at path.
+func OpenBoltCache(path string) (*BoltCache, error) {
+	db, err := bbolt.Open(path, 0600, &bbolt.Options{Timeout: time.Second})
+	if err != nil {
+		return nil, fmt.Errorf("boltcache: failed to open %s: %w", path, err)
+	}
+
+	err = db.Update(func(tx *bbolt.Tx) error {
+		if _, err := tx.CreateBucketIfNotExists(boltEntriesBucket); err != nil {
+			return err
+		}
+		_, err := tx.CreateBucketIfNotExists(boltNegativeBucket)
+		return err
+	})
+	if err != nil {
+		db.Close()
+		return nil, fmt.Errorf("boltcache: failed to initialize buckets: %w", err)
+	}
+
+	return &BoltCache{db: db}, nil
+}
+
+// Close releases the underlying BoltDB file handle.
+func (c *BoltCache) Close() error {
+	return c.db.Close()
+}
+
+type boltEntry struct {
+	Price     float64   `json:"price"`
+	Timestamp time.Time `json:"timestamp"`
+	FetchedAt time.Time `json:"fetchedAt"`
+}
+
+func (c *BoltCache) Get(token, source string) (CacheEntry, bool) {
+	var entry CacheEntry
+	var found bool
+
+	c.db.View(func(tx *bbolt.Tx) error {
+		raw := tx.Bucket(boltEntriesBucket).Get([]byte(cacheKey(token, source)))
+		if raw == nil {
+			return nil
+		}
+		var be boltEntry
+		if err := json.Unmarshal(raw, &be); err != nil {
+			return nil
+		}
+		entry = CacheEntry{Price: be.Price, Timestamp: be.Timestamp, FetchedAt: be.FetchedAt}
+		found = true
+		return nil
+	})
+
+	return entry, found
+}
+
+func (c *BoltCache) Set(token, source string, entry CacheEntry) {
+	c.db.Update(func(tx *bbolt.Tx) error {
+		raw, err := json.Marshal(boltEntry{Price: entry.Price, Timestamp: entry.Timestamp, FetchedAt: entry.FetchedAt})
+		if err != nil {
+			return err
+		}
+		if err := tx.Bucket(boltEntriesBucket).Put([]byte(cacheKey(token, source)), raw); err != nil {
+			return err
+		}
+		return tx.Bucket(boltNegativeBucket).Delete([]byte(cacheKey(token, source)))
+	})
+}
+
+func (c *BoltCache) SetNegative(token, source string) {
+	c.db.Update(func(tx *bbolt.Tx) error {
+		raw, err := time.Now().MarshalBinary()
+		if err != nil {
+			return err
+		}
+		return tx.Bucket(boltNegativeBucket).Put([]byte(cacheKey(token, source)), raw)
+	})
+}
+
+func (c *BoltCache) IsNegative(token, source string, ttl time.Duration) bool {
+	var negative bool
+	c.db.View(func(tx *bbolt.Tx) error {
+		raw := tx.Bucket(boltNegativeBucket).Get([]byte(cacheKey(token, source)))
+		if raw == nil {
+			return nil
+		}
+		var setAt time.Time
+		if err := setAt.UnmarshalBinary(raw); err != nil {
+			return nil
+		}
+		negative = time.Since(setAt) <= ttl
+		return nil
+	})
+	return negative
+}
@@ -0,0 +1,203 @@
+package go_arkham_oracle_sdk
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+// CacheEntry is what a PriceCache stores for a (token, source) pair.
+type CacheEntry struct {
+	Price     float64
+	Timestamp time.Time // when the upstream source says the price is from
+	FetchedAt time.Time // when this SDK fetched it
+}
+
+// PriceCache is consulted before hitting upstream price feeds. Implementations
+// must be safe for concurrent use.
+type PriceCache interface {
+	// Get returns the cached entry for (token, source), if any.
+	Get(token, source string) (CacheEntry, bool)
+	// Set stores entry for (token, source).
+	Set(token, source string, entry CacheEntry)
+	// SetNegative records that (token, source) was not found upstream, so
+	// repeated lookups can be short-circuited until ttl elapses.
+	SetNegative(token, source string)
+	// IsNegative reports whether (token, source) was negatively cached within
+	// the last ttl.
+	IsNegative(token, source string, ttl time.Duration) bool
+}
+
+var (
+	cacheHitsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "arkham_oracle_cache_hits_total",
+		Help: "Number of price lookups served from cache.",
+	}, []string{"source"})
+	cacheMissesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "arkham_oracle_cache_misses_total",
+		Help: "Number of price lookups that required an upstream fetch.",
+	}, []string{"source"})
+	cacheNegativeHitsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "arkham_oracle_cache_negative_hits_total",
+		Help: "Number of price lookups short-circuited by the negative cache.",
+	}, []string{"source"})
+)
+
+// defaultMaxStaleness and defaultNegativeTTL are used whenever CachedSource's
+// corresponding fields are left at their zero value, so simply setting Cache
+// without also setting these doesn't silently disable staleness-reuse and
+// negative-caching (time.Since(x) <= 0 is essentially always false).
+const (
+	defaultMaxStaleness = 30 * time.Second
+	defaultNegativeTTL  = 5 * time.Minute
+)
+
+// CachedSource wraps a PriceSource with a PriceCache, so repeated lookups for
+// the same token within MaxStaleness are served from cache instead of
+// hammering upstream, and tokens the upstream doesn't recognize are
+// negatively cached for NegativeTTL instead of being re-fetched every request.
+// It implements PriceSource, so it drops directly into the aggregator.
+type CachedSource struct {
+	Inner PriceSource
+	Cache PriceCache
+	// MaxStaleness bounds how old a cached entry may be and still be reused.
+	// Defaults to 30 seconds.
+	MaxStaleness time.Duration
+	// NegativeTTL bounds how long a "not found" result is remembered before
+	// the upstream is retried. Defaults to 5 minutes.
+	NegativeTTL time.Duration
+}
+
+func (c *CachedSource) Name() string {
+	return c.Inner.Name()
+}
+
+func (c *CachedSource) maxStaleness() time.Duration {
+	if c.MaxStaleness > 0 {
+		return c.MaxStaleness
+	}
+	return defaultMaxStaleness
+}
+
+func (c *CachedSource) negativeTTL() time.Duration {
+	if c.NegativeTTL > 0 {
+		return c.NegativeTTL
+	}
+	return defaultNegativeTTL
+}
+
+func (c *CachedSource) FetchQuote(token string) (Quote, error) {
+	source := c.Name()
+
+	if entry, ok := c.Cache.Get(token, source); ok {
+		if time.Since(entry.FetchedAt) <= c.maxStaleness() {
+			cacheHitsTotal.WithLabelValues(source).Inc()
+			return Quote{Source: source, Token: token, Price: entry.Price, Timestamp: entry.Timestamp}, nil
+		}
+	}
+
+	if c.Cache.IsNegative(token, source, c.negativeTTL()) {
+		cacheNegativeHitsTotal.WithLabelValues(source).Inc()
+		return Quote{}, fmt.Errorf("%s: price for token '%s' not found (negatively cached)", source, token)
+	}
+
+	cacheMissesTotal.WithLabelValues(source).Inc()
+	quote, err := c.Inner.FetchQuote(token)
+	if err != nil {
+		c.Cache.SetNegative(token, source)
+		return Quote{}, err
+	}
+
+	c.Cache.Set(token, source, CacheEntry{Price: quote.Price, Timestamp: quote.Timestamp, FetchedAt: time.Now()})
+	return quote, nil
+}
+
+// LRUCache is an in-memory PriceCache bounded to Capacity entries, evicting
+// the least recently used entry once full.
+type LRUCache struct {
+	Capacity int
+
+	mu       sync.Mutex
+	entries  map[string]*list.Element
+	order    *list.List
+	negative map[string]time.Time
+}
+
+type lruItem struct {
+	key   string
+	entry CacheEntry
+}
+
+// NewLRUCache creates an in-memory PriceCache holding up to capacity entries.
+func NewLRUCache(capacity int) *LRUCache {
+	if capacity <= 0 {
+		capacity = 1024
+	}
+	return &LRUCache{
+		Capacity: capacity,
+		entries:  make(map[string]*list.Element),
+		order:    list.New(),
+		negative: make(map[string]time.Time),
+	}
+}
+
+func cacheKey(token, source string) string {
+	return source + "|" + token
+}
+
+func (c *LRUCache) Get(token, source string) (CacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[cacheKey(token, source)]
+	if !ok {
+		return CacheEntry{}, false
+	}
+	c.order.MoveToFront(elem)
+	return elem.Value.(*lruItem).entry, true
+}
+
+func (c *LRUCache) Set(token, source string, entry CacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	key := cacheKey(token, source)
+	delete(c.negative, key)
+
+	if elem, ok := c.entries[key]; ok {
+		elem.Value.(*lruItem).entry = entry
+		c.order.MoveToFront(elem)
+		return
+	}
+
+	elem := c.order.PushFront(&lruItem{key: key, entry: entry})
+	c.entries[key] = elem
+
+	if c.order.Len() > c.Capacity {
+		oldest := c.order.Back()
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, oldest.Value.(*lruItem).key)
+		}
+	}
+}
+
+func (c *LRUCache) SetNegative(token, source string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.negative[cacheKey(token, source)] = time.Now()
+}
+
+func (c *LRUCache) IsNegative(token, source string, ttl time.Duration) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	setAt, ok := c.negative[cacheKey(token, source)]
+	if !ok {
+		return false
+	}
+	return time.Since(setAt) <= ttl
+}
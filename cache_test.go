@@ -0,0 +1,147 @@
+package go_arkham_oracle_sdk
+
+import (
+	"context"
+	"errors"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+type stubSource struct {
+	name  string
+	calls int
+	price float64
+	err   error
+}
+
+func (s *stubSource) Name() string { return s.name }
+
+func (s *stubSource) FetchQuote(token string) (Quote, error) {
+	s.calls++
+	if s.err != nil {
+		return Quote{}, s.err
+	}
+	return Quote{Source: s.name, Token: token, Price: s.price, Timestamp: time.Now()}, nil
+}
+
+func TestLRUCacheEvictsOldest(t *testing.T) {
+	c := NewLRUCache(2)
+	c.Set("a", "src", CacheEntry{Price: 1})
+	c.Set("b", "src", CacheEntry{Price: 2})
+	c.Set("c", "src", CacheEntry{Price: 3}) // evicts "a", the least recently used
+
+	if _, ok := c.Get("a", "src"); ok {
+		t.Fatalf("entry for 'a' should have been evicted")
+	}
+	if _, ok := c.Get("b", "src"); !ok {
+		t.Fatalf("entry for 'b' should still be cached")
+	}
+}
+
+func TestLRUCacheNegativeCaching(t *testing.T) {
+	c := NewLRUCache(8)
+	c.SetNegative("token", "src")
+	if !c.IsNegative("token", "src", time.Minute) {
+		t.Fatalf("IsNegative() = false, want true within ttl")
+	}
+	if c.IsNegative("token", "src", 0) {
+		t.Fatalf("IsNegative() with a zero ttl should be false once any time has elapsed")
+	}
+
+	c.Set("token", "src", CacheEntry{Price: 5})
+	if c.IsNegative("token", "src", time.Minute) {
+		t.Fatalf("a fresh Set should clear the negative cache entry")
+	}
+}
+
+func TestCachedSourceDefaultsStillServeFromCache(t *testing.T) {
+	inner := &stubSource{name: "inner", price: 100}
+	cache := NewLRUCache(8)
+	cs := &CachedSource{Inner: inner, Cache: cache} // MaxStaleness/NegativeTTL left at zero value
+
+	if _, err := cs.FetchQuote("ETH"); err != nil {
+		t.Fatalf("first FetchQuote: %v", err)
+	}
+	if _, err := cs.FetchQuote("ETH"); err != nil {
+		t.Fatalf("second FetchQuote: %v", err)
+	}
+
+	if inner.calls != 1 {
+		t.Fatalf("inner.calls = %d, want 1 (second call should have been served from the default-staleness cache)", inner.calls)
+	}
+}
+
+func TestCachedSourceDefaultNegativeTTLSuppressesRefetch(t *testing.T) {
+	inner := &stubSource{name: "inner", err: errors.New("not found")}
+	cache := NewLRUCache(8)
+	cs := &CachedSource{Inner: inner, Cache: cache}
+
+	if _, err := cs.FetchQuote("ETH"); err == nil {
+		t.Fatalf("expected an error from the first fetch")
+	}
+	if _, err := cs.FetchQuote("ETH"); err == nil {
+		t.Fatalf("expected a negative-cache error from the second fetch")
+	}
+
+	if inner.calls != 1 {
+		t.Fatalf("inner.calls = %d, want 1 (second lookup should have hit the default negative cache)", inner.calls)
+	}
+}
+
+func TestBoltCacheRoundTrip(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache.db")
+	bc, err := OpenBoltCache(path)
+	if err != nil {
+		t.Fatalf("OpenBoltCache: %v", err)
+	}
+	defer bc.Close()
+
+	bc.Set("ETH", "src", CacheEntry{Price: 42, Timestamp: time.Now(), FetchedAt: time.Now()})
+	entry, ok := bc.Get("ETH", "src")
+	if !ok {
+		t.Fatalf("Get() = not found, want an entry")
+	}
+	if entry.Price != 42 {
+		t.Fatalf("Price = %v, want 42", entry.Price)
+	}
+
+	bc.SetNegative("BTC", "src")
+	if !bc.IsNegative("BTC", "src", time.Minute) {
+		t.Fatalf("IsNegative() = false, want true within ttl")
+	}
+	if bc.IsNegative("BTC", "src", 0) {
+		t.Fatalf("IsNegative() with a zero ttl should be false")
+	}
+}
+
+func TestCacheRefresherRefreshOnce(t *testing.T) {
+	inner := &stubSource{name: "inner", price: 9}
+	r := &CacheRefresher{Sources: []PriceSource{inner}, Tokens: []string{"ETH", "BTC"}}
+
+	r.refreshOnce()
+
+	if inner.calls != 2 {
+		t.Fatalf("inner.calls = %d, want 2 (one per hot token)", inner.calls)
+	}
+}
+
+func TestCacheRefresherStartStopsOnContextCancel(t *testing.T) {
+	inner := &stubSource{name: "inner", price: 9}
+	r := &CacheRefresher{Sources: []PriceSource{inner}, Tokens: []string{"ETH"}, Interval: 10 * time.Millisecond}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	r.Start(ctx)
+	time.Sleep(35 * time.Millisecond)
+	cancel()
+	time.Sleep(20 * time.Millisecond)
+
+	callsAtCancel := inner.calls
+	time.Sleep(30 * time.Millisecond)
+	if inner.calls != callsAtCancel {
+		t.Fatalf("refresher kept ticking after ctx was canceled: calls went from %d to %d", callsAtCancel, inner.calls)
+	}
+	if callsAtCancel == 0 {
+		t.Fatalf("expected at least one refresh tick before cancellation")
+	}
+}
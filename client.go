@@ -2,41 +2,81 @@ package go_arkham_oracle_sdk
 
 import (
 	"bytes"
+	"crypto/rand"
 	"encoding/binary"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"math/big"
 	"net/http"
 	"net/url"
 	"strconv"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/crypto/sha3"
 )
 
-// SignedPriceData holds the data returned from the oracle API.
-type SignedPriceData struct {
-	Price     uint64
-	Timestamp int64
-	Signature []byte
-}
-
 // internal struct for JSON unmarshalling of oracle API response
 type priceResponse struct {
-	Price     string `json:"price"`
-	Timestamp string `json:"timestamp"`
-	Signature string `json:"signature"`
-}
-
-// internal struct for JSON unmarshalling of CoinGecko-like price data
-type CoinGeckoPriceResponse map[string]struct {
-	Usd float64 `json:"usd"`
+	Price             string `json:"price"`
+	Timestamp         string `json:"timestamp"`
+	Method            string `json:"method"`
+	SourceCount       string `json:"sourceCount"`
+	Scheme            string `json:"scheme"`
+	TokenAddress      string `json:"tokenAddress"`
+	ChainID           string `json:"chainId"`
+	VerifyingContract string `json:"verifyingContract"`
+	KeyID             string `json:"keyId"`
+	KeyExpiresAt      string `json:"keyExpiresAt"`
+	Signature         string `json:"signature"`
 }
 
 // Client is a client for the Arkham Oracle API.
 type Client struct {
-	BaseURL string
+	BaseURL       string
 	DataSourceURL string // Optional: URL for an alternative data source
+	// Optional. A CoinGecko Pro API key, used by the default CoinGecko source.
+	CoinGeckoAPIKey string
+	// Optional. Overrides for symbol-to-CoinGecko-ID resolution on the default
+	// CoinGecko source, e.g. {"ETH": "ethereum"}.
+	CoinGeckoSymbolMap map[string]string
+
+	// Sources optionally lists additional PriceSource adapters to aggregate
+	// over when computing the local pre-fetch price sent alongside the
+	// request. If empty, the client falls back to a single source: a
+	// CoinGeckoProvider, or, if DataSourceURL is set, the original hard-coded
+	// data source behavior.
+	Sources []PriceSource
+	// Strategy selects how quotes from Sources are combined. Defaults to
+	// StrategyMedian.
+	Strategy AggregationStrategy
+	// MaxDeviationBps discards any local sample whose deviation from the
+	// median exceeds this many basis points. Zero disables outlier rejection.
+	MaxDeviationBps uint32
+	// MinSources is the minimum number of surviving local samples required.
+	// Defaults to 1.
+	MinSources int
+
+	// Cache, if set, is consulted before each configured source is hit and
+	// populated after, wrapping every source in a CachedSource.
+	Cache PriceCache
+	// MaxStaleness bounds how old a cached entry may be and still be reused.
+	// Ignored if Cache is nil.
+	MaxStaleness time.Duration
+	// NegativeTTL bounds how long a "token not found" result is remembered
+	// before the upstream source is retried. Ignored if Cache is nil.
+	NegativeTTL time.Duration
+
+	// KeyID and Secret, if both set, make FetchSignedPrice transparently sign
+	// each oracle request per HMACAuthPolicy: X-Arkham-KeyID,
+	// X-Arkham-Timestamp, X-Arkham-Nonce, and X-Arkham-Signature headers.
+	KeyID  string
+	Secret string
+
+	sourcesOnce     sync.Once
+	resolvedSources []PriceSource
 }
 
 // NewClient creates a new oracle client.
@@ -50,35 +90,84 @@ func NewClient(baseURL string, dataSourceURL ...string) *Client {
 	return client
 }
 
-// FetchSignedPrice fetches signed price data from the oracle.
-// The trustedKey is optional. If provided, it will be sent as a query parameter.
-func (c *Client) FetchSignedPrice(token string, trustedKey ...string) (*SignedPriceData, error) {
-	// Determine the URL to fetch the raw price data from
-	priceSourceURL := fmt.Sprintf("https://api.coingecko.com/api/v3/simple/price?ids=%s&vs_currencies=usd", token)
-	if c.DataSourceURL != "" {
-		// Assuming the custom data source uses similar query parameters
-		priceSourceURL = fmt.Sprintf("%s?ids=%s&vs_currencies=usd", c.DataSourceURL, token)
+// attachAuthHeaders signs req per HMACAuthPolicy when both KeyID and Secret
+// are set, so callers only need to set those two fields on the Client.
+// NewClient does not take them as arguments; they are assigned on the
+// returned Client.
+func (c *Client) attachAuthHeaders(req *http.Request) {
+	if c.KeyID == "" || c.Secret == "" {
+		return
 	}
 
-	// Fetch raw price data from the determined source
-	priceResp, err := http.Get(priceSourceURL)
-	if err != nil {
-		return nil, fmt.Errorf("failed to fetch raw price data from %s: %w", priceSourceURL, err)
-	}
-	defer priceResp.Body.Close()
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	nonce := hex.EncodeToString(randomNonce())
+	signature := hmacSignature(c.Secret, c.KeyID, timestamp, nonce, req.Method, req.URL.Path, req.URL.Query().Encode())
 
-	if priceResp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("raw price data source returned non-200 status: %s", priceResp.Status)
-	}
+	req.Header.Set("X-Arkham-KeyID", c.KeyID)
+	req.Header.Set("X-Arkham-Timestamp", timestamp)
+	req.Header.Set("X-Arkham-Nonce", nonce)
+	req.Header.Set("X-Arkham-Signature", signature)
+}
 
-	var rawPriceData CoinGeckoPriceResponse
-	if err := json.NewDecoder(priceResp.Body).Decode(&rawPriceData); err != nil {
-		return nil, fmt.Errorf("failed to decode raw price data: %w", err)
-	}
+// randomNonce returns 16 cryptographically random bytes for use as an
+// HMAC request nonce.
+func randomNonce() []byte {
+	b := make([]byte, 16)
+	_, _ = rand.Read(b)
+	return b
+}
+
+// sources lazily resolves and caches the PriceSource list (defaulting and
+// wrapping in CachedSource as needed) on first use, so a long-lived Client
+// polling FetchSignedPrice repeatedly doesn't grow a new CachedSource layer
+// around the same inner adapter on every call.
+func (c *Client) sources() []PriceSource {
+	c.sourcesOnce.Do(func() {
+		sources := append([]PriceSource(nil), c.Sources...)
+		if len(sources) == 0 {
+			if c.DataSourceURL != "" {
+				sources = []PriceSource{&defaultCoinGeckoSource{dataSourceURL: c.DataSourceURL}}
+			} else {
+				sources = []PriceSource{&CoinGeckoProvider{
+					APIKey:    c.CoinGeckoAPIKey,
+					SymbolMap: c.CoinGeckoSymbolMap,
+				}}
+			}
+		}
 
-	priceFloat := rawPriceData[token].Usd
-	if priceFloat == 0 { // CoinGecko returns 0 if token not found
-		return nil, fmt.Errorf("price for token '%s' not found in data source response", token)
+		if c.Cache != nil {
+			for i, src := range sources {
+				sources[i] = &CachedSource{
+					Inner:        src,
+					Cache:        c.Cache,
+					MaxStaleness: c.MaxStaleness,
+					NegativeTTL:  c.NegativeTTL,
+				}
+			}
+		}
+
+		c.resolvedSources = sources
+	})
+	return c.resolvedSources
+}
+
+// FetchSignedPrice fetches signed price data from the oracle.
+// The trustedKey is optional. If provided, it will be sent as a query parameter.
+func (c *Client) FetchSignedPrice(token string, trustedKey ...string) (*SignedPriceData, error) {
+	// Fetch (and aggregate, if multiple Sources are configured) the raw price
+	// data used for the local pre-fetch sent alongside the request.
+	sources := c.sources()
+
+	aggregator := NewAggregator(AggregatorOptions{
+		Strategy:        c.Strategy,
+		MaxDeviationBps: c.MaxDeviationBps,
+		MinSources:      c.MinSources,
+	})
+	fetchQuotes(sources, token, aggregator)
+
+	priceFloat, _, err := aggregator.Aggregate(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch raw price data for '%s': %w", token, err)
 	}
 
 	// Convert price to micro-units (6 decimals) as a uint64
@@ -97,7 +186,13 @@ func (c *Client) FetchSignedPrice(token string, trustedKey ...string) (*SignedPr
 	}
 	reqURL := fmt.Sprintf("%s?%s", c.BaseURL, params.Encode())
 
-	resp, err := http.Get(reqURL)
+	req, err := http.NewRequest(http.MethodGet, reqURL, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build oracle API request: %w", err)
+	}
+	c.attachAuthHeaders(req)
+
+	resp, err := http.DefaultClient.Do(req)
 	if err != nil {
 		return nil, fmt.Errorf("failed to call oracle API: %w", err)
 	}
@@ -129,24 +224,64 @@ func (c *Client) FetchSignedPrice(token string, trustedKey ...string) (*SignedPr
 	if err != nil {
 		return nil, fmt.Errorf("failed to decode signature from API: %w", err)
 	}
+	sourceCount, err := strconv.Atoi(rawResp.SourceCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse sourceCount from API: %w", err)
+	}
 
 	return &SignedPriceData{
-		Price:     price,
-		Timestamp: timestamp,
-		Signature: signature,
+		Price:             price,
+		Timestamp:         timestamp,
+		Method:            rawResp.Method,
+		SourceCount:       sourceCount,
+		Scheme:            rawResp.Scheme,
+		TokenAddress:      rawResp.TokenAddress,
+		ChainID:           rawResp.ChainID,
+		VerifyingContract: rawResp.VerifyingContract,
+		KeyID:             rawResp.KeyID,
+		KeyExpiresAt:      rawResp.KeyExpiresAt,
+		Signature:         signature,
 	}, nil
 }
 
-// CreateOracleMessageHash reconstructs the 32-byte Keccak-256 hash from the price and timestamp.
-// This is the message that was signed by the oracle.
-func (d *SignedPriceData) CreateOracleMessageHash() ([]byte, error) {
+// CreateOracleMessageHash reconstructs the message hash that was signed by
+// the oracle. keyID should be the KeyID returned alongside the signature
+// (d.KeyID); it is folded into the pre-image under SchemeEd25519Keccak so a
+// signature produced under a stale key can't be verified as if it came from
+// a different one. Under SchemeEVMSecp256k1, keyID is ignored and the EIP-712
+// digest is reconstructed instead from TokenAddress, ChainID, and
+// VerifyingContract, so Solidity contracts using ecrecover can verify the
+// payload directly. The EIP-712 domain name and version used here are always
+// the defaults ("ArkhamOracle", "1"); a handler configured with a different
+// EVMSigningConfig.DomainName or DomainVersion signs a digest that this does
+// not reproduce.
+func (d *SignedPriceData) CreateOracleMessageHash(keyID string) ([]byte, error) {
+	if SigningScheme(d.Scheme) == SchemeEVMSecp256k1 {
+		chainID, ok := new(big.Int).SetString(d.ChainID, 10)
+		if !ok {
+			return nil, fmt.Errorf("failed to parse chainId %q", d.ChainID)
+		}
+		return reconstructEIP712Digest(
+			defaultDomainName,
+			defaultDomainVersion,
+			chainID,
+			common.HexToAddress(d.VerifyingContract),
+			common.HexToAddress(d.TokenAddress),
+			d.Price,
+			uint64(d.Timestamp),
+		), nil
+	}
+
 	buf := new(bytes.Buffer)
+	buf.Write(keyIDDigest(keyID))
 	if err := binary.Write(buf, binary.LittleEndian, d.Price); err != nil {
 		return nil, err
 	}
 	if err := binary.Write(buf, binary.LittleEndian, d.Timestamp); err != nil {
 		return nil, err
 	}
+	buf.WriteByte(AggregationStrategy(d.Method).methodID())
+	buf.WriteByte(byte(d.SourceCount))
 
 	hasher := sha3.NewLegacyKeccak256()
 	hasher.Write(buf.Bytes())
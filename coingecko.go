@@ -0,0 +1,259 @@
+package go_arkham_oracle_sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	coinGeckoPublicBaseURL = "https://api.coingecko.com/api/v3"
+	coinGeckoProBaseURL    = "https://pro-api.coingecko.com/api/v3"
+
+	defaultCoinListTTL = time.Hour
+	maxRetries         = 4
+)
+
+// CoinGeckoProvider is a PriceSource backed by the CoinGecko API. It resolves
+// user-friendly symbols (e.g. "ETH", "USDC") to CoinGecko coin IDs (e.g.
+// "ethereum", "usd-coin") via a cached /coins/list lookup, supports Pro API
+// keys, honors 429 rate limiting with backoff, and can look up tokens by
+// contract address when they aren't in the symbol map.
+//
+// With no APIKey and no explicit SymbolMap, a CoinGeckoProvider behaves like
+// the SDK's original hard-coded CoinGecko integration: the token is passed
+// straight through as a CoinGecko ID.
+type CoinGeckoProvider struct {
+	// APIKey, if set, is sent as X-Cg-Pro-Api-Key and switches the provider to
+	// pro-api.coingecko.com.
+	APIKey string
+	// SymbolMap optionally overrides symbol-to-ID resolution, e.g.
+	// {"ETH": "ethereum"}. Checked before the cached /coins/list lookup.
+	SymbolMap map[string]string
+	// ContractPlatform is the CoinGecko asset platform used for contract
+	// address lookups (e.g. "ethereum", "polygon-pos"). Defaults to "ethereum".
+	ContractPlatform string
+	// ListTTL controls how long the /coins/list cache is reused before being
+	// refreshed. Defaults to 1 hour.
+	ListTTL time.Duration
+	// HTTPClient is used for all requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+
+	mu            sync.Mutex
+	idsBySymbol   map[string]string // lower-cased symbol -> coingecko id
+	listFetchedAt time.Time
+}
+
+func (p *CoinGeckoProvider) Name() string { return "coingecko" }
+
+func (p *CoinGeckoProvider) FetchQuote(token string) (Quote, error) {
+	if strings.HasPrefix(token, "0x") && len(token) == 42 {
+		price, err := p.fetchByContract(token)
+		if err != nil {
+			return Quote{}, err
+		}
+		return Quote{Source: p.Name(), Token: token, Price: price, Timestamp: time.Now()}, nil
+	}
+
+	id := p.resolveID(token)
+	priceFloat, err := p.fetchSimplePrice(id)
+	if err == nil && priceFloat != 0 {
+		return Quote{Source: p.Name(), Token: token, Price: priceFloat, Timestamp: time.Now()}, nil
+	}
+
+	// No SymbolMap entry and the raw token wasn't a valid id either: fall
+	// back to the cached /coins/list symbol index before giving up. Kept as
+	// a fallback rather than the first attempt so the common case (no key,
+	// no symbol map, token already a valid id) stays a single /simple/price
+	// call, matching the SDK's original behavior.
+	if _, ok := p.SymbolMap[token]; !ok {
+		if ids, listErr := p.coinList(); listErr == nil {
+			if resolvedID, ok := ids[strings.ToLower(token)]; ok && resolvedID != id {
+				if priceFloat, err := p.fetchSimplePrice(resolvedID); err == nil && priceFloat != 0 {
+					return Quote{Source: p.Name(), Token: token, Price: priceFloat, Timestamp: time.Now()}, nil
+				}
+			}
+		}
+	}
+
+	return Quote{}, fmt.Errorf("coingecko: price for token '%s' not found", token)
+}
+
+// resolveID turns a user-supplied token into a CoinGecko coin ID using
+// SymbolMap, falling back to treating token as an ID already (the original
+// behavior). The cached /coins/list symbol index is only consulted as a
+// fallback in FetchQuote, so a provider with no SymbolMap never pays for a
+// full list fetch on its common-case request.
+func (p *CoinGeckoProvider) resolveID(token string) string {
+	if id, ok := p.SymbolMap[token]; ok {
+		return id
+	}
+	return token
+}
+
+// fetchSimplePrice fetches id's USD price via /simple/price.
+func (p *CoinGeckoProvider) fetchSimplePrice(id string) (float64, error) {
+	url := fmt.Sprintf("%s/simple/price?ids=%s&vs_currencies=usd", p.baseURL(), id)
+	body, err := p.getWithRetry(url)
+	if err != nil {
+		return 0, fmt.Errorf("coingecko: %w", err)
+	}
+
+	var priceData CoinGeckoPriceResponse
+	if err := json.Unmarshal(body, &priceData); err != nil {
+		return 0, fmt.Errorf("coingecko: failed to decode price data: %w", err)
+	}
+	return priceData[id].Usd, nil
+}
+
+// coinList returns the cached symbol->id map, refreshing it from
+// /coins/list if it is missing or older than ListTTL.
+func (p *CoinGeckoProvider) coinList() (map[string]string, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	ttl := p.ListTTL
+	if ttl <= 0 {
+		ttl = defaultCoinListTTL
+	}
+	if p.idsBySymbol != nil && time.Since(p.listFetchedAt) < ttl {
+		return p.idsBySymbol, nil
+	}
+
+	body, err := p.getWithRetry(fmt.Sprintf("%s/coins/list", p.baseURL()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to refresh coin list: %w", err)
+	}
+
+	var coins []struct {
+		ID     string `json:"id"`
+		Symbol string `json:"symbol"`
+	}
+	if err := json.Unmarshal(body, &coins); err != nil {
+		return nil, fmt.Errorf("failed to decode coin list: %w", err)
+	}
+
+	ids := make(map[string]string, len(coins))
+	for _, c := range coins {
+		// Later entries win on symbol collisions; CoinGecko doesn't guarantee
+		// symbols are unique, so this is best-effort.
+		ids[strings.ToLower(c.Symbol)] = c.ID
+	}
+
+	p.idsBySymbol = ids
+	p.listFetchedAt = time.Now()
+	return ids, nil
+}
+
+// fetchByContract looks up a token's USD price by its contract address via
+// /coins/{platform}/contract/{address}, for tokens missing from the symbol map.
+func (p *CoinGeckoProvider) fetchByContract(address string) (float64, error) {
+	platform := p.ContractPlatform
+	if platform == "" {
+		platform = "ethereum"
+	}
+
+	url := fmt.Sprintf("%s/coins/%s/contract/%s", p.baseURL(), platform, address)
+	body, err := p.getWithRetry(url)
+	if err != nil {
+		return 0, fmt.Errorf("coingecko: contract lookup failed: %w", err)
+	}
+
+	var coin struct {
+		MarketData struct {
+			CurrentPrice map[string]float64 `json:"current_price"`
+		} `json:"market_data"`
+	}
+	if err := json.Unmarshal(body, &coin); err != nil {
+		return 0, fmt.Errorf("coingecko: failed to decode contract lookup: %w", err)
+	}
+
+	priceFloat := coin.MarketData.CurrentPrice["usd"]
+	if priceFloat == 0 {
+		return 0, fmt.Errorf("coingecko: price for contract '%s' not found", address)
+	}
+	return priceFloat, nil
+}
+
+func (p *CoinGeckoProvider) baseURL() string {
+	if p.APIKey != "" {
+		return coinGeckoProBaseURL
+	}
+	return coinGeckoPublicBaseURL
+}
+
+func (p *CoinGeckoProvider) httpClient() *http.Client {
+	if p.HTTPClient != nil {
+		return p.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// getWithRetry issues a GET request, retrying with exponential backoff plus
+// jitter when CoinGecko returns 429, honoring Retry-After if present.
+func (p *CoinGeckoProvider) getWithRetry(url string) ([]byte, error) {
+	var lastErr error
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		req, err := http.NewRequest(http.MethodGet, url, nil)
+		if err != nil {
+			return nil, err
+		}
+		if p.APIKey != "" {
+			req.Header.Set("X-Cg-Pro-Api-Key", p.APIKey)
+		}
+
+		resp, err := p.httpClient().Do(req)
+		if err != nil {
+			lastErr = err
+			time.Sleep(backoff(attempt))
+			continue
+		}
+
+		if resp.StatusCode == http.StatusTooManyRequests {
+			wait := retryAfter(resp.Header.Get("Retry-After"))
+			if wait == 0 {
+				wait = backoff(attempt)
+			}
+			resp.Body.Close()
+			lastErr = fmt.Errorf("rate limited (429)")
+			time.Sleep(wait)
+			continue
+		}
+
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		if resp.StatusCode != http.StatusOK {
+			return nil, fmt.Errorf("unexpected status %s", resp.Status)
+		}
+		return body, nil
+	}
+	return nil, fmt.Errorf("exceeded %d retries: %w", maxRetries, lastErr)
+}
+
+// retryAfter parses a Retry-After header value (seconds) into a duration.
+func retryAfter(header string) time.Duration {
+	if header == "" {
+		return 0
+	}
+	seconds, err := strconv.Atoi(header)
+	if err != nil || seconds <= 0 {
+		return 0
+	}
+	return time.Duration(seconds) * time.Second
+}
+
+// backoff computes an exponential backoff duration with jitter for attempt n.
+func backoff(attempt int) time.Duration {
+	base := time.Duration(1<<uint(attempt)) * 200 * time.Millisecond
+	jitter := time.Duration(rand.Int63n(int64(base) + 1))
+	return base + jitter
+}
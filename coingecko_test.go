@@ -0,0 +1,147 @@
+package go_arkham_oracle_sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// rewriteTransport redirects every request to target, regardless of what
+// host/scheme the request was built against, so CoinGeckoProvider's
+// hard-coded api.coingecko.com/pro-api.coingecko.com URLs can be exercised
+// against an httptest.Server.
+type rewriteTransport struct {
+	target *url.URL
+}
+
+func (rt *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req.URL.Scheme = rt.target.Scheme
+	req.URL.Host = rt.target.Host
+	return http.DefaultTransport.RoundTrip(req)
+}
+
+func newTestCoinGeckoProvider(t *testing.T, srv *httptest.Server) *CoinGeckoProvider {
+	t.Helper()
+	target, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatalf("url.Parse: %v", err)
+	}
+	return &CoinGeckoProvider{
+		HTTPClient: &http.Client{Transport: &rewriteTransport{target: target}},
+	}
+}
+
+func TestCoinGeckoProviderFetchQuoteNoSymbolMapSkipsCoinList(t *testing.T) {
+	var sawCoinList bool
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/coins/list") {
+			sawCoinList = true
+			w.Write([]byte(`[]`))
+			return
+		}
+		w.Write([]byte(`{"ethereum":{"usd":2000}}`))
+	}))
+	defer srv.Close()
+
+	p := newTestCoinGeckoProvider(t, srv)
+	quote, err := p.FetchQuote("ethereum")
+	if err != nil {
+		t.Fatalf("FetchQuote: %v", err)
+	}
+	if quote.Price != 2000 {
+		t.Fatalf("Price = %v, want 2000", quote.Price)
+	}
+	if sawCoinList {
+		t.Fatalf("FetchQuote hit /coins/list even though the raw token resolved directly; should only fall back on a miss")
+	}
+}
+
+func TestCoinGeckoProviderFetchQuoteFallsBackToCoinList(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "/coins/list"):
+			w.Write([]byte(`[{"id":"ethereum","symbol":"eth"}]`))
+		case strings.Contains(r.URL.RawQuery, "ids=ethereum"):
+			w.Write([]byte(`{"ethereum":{"usd":3000}}`))
+		default:
+			w.Write([]byte(`{}`))
+		}
+	}))
+	defer srv.Close()
+
+	p := newTestCoinGeckoProvider(t, srv)
+	quote, err := p.FetchQuote("ETH")
+	if err != nil {
+		t.Fatalf("FetchQuote: %v", err)
+	}
+	if quote.Price != 3000 {
+		t.Fatalf("Price = %v, want 3000 (resolved via /coins/list fallback)", quote.Price)
+	}
+}
+
+func TestCoinGeckoProviderSymbolMapTakesPriority(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.Contains(r.URL.Path, "/coins/list") {
+			t.Errorf("should not consult /coins/list when SymbolMap already resolves the token")
+		}
+		w.Write([]byte(`{"ethereum":{"usd":2500}}`))
+	}))
+	defer srv.Close()
+
+	p := newTestCoinGeckoProvider(t, srv)
+	p.SymbolMap = map[string]string{"ETH": "ethereum"}
+
+	quote, err := p.FetchQuote("ETH")
+	if err != nil {
+		t.Fatalf("FetchQuote: %v", err)
+	}
+	if quote.Price != 2500 {
+		t.Fatalf("Price = %v, want 2500", quote.Price)
+	}
+}
+
+func TestCoinGeckoProviderFetchByContract(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.Contains(r.URL.Path, "/coins/ethereum/contract/") {
+			t.Fatalf("unexpected path %s", r.URL.Path)
+		}
+		w.Write([]byte(`{"market_data":{"current_price":{"usd":11.5}}}`))
+	}))
+	defer srv.Close()
+
+	p := newTestCoinGeckoProvider(t, srv)
+	quote, err := p.FetchQuote("0x1234567890123456789012345678901234567890")
+	if err != nil {
+		t.Fatalf("FetchQuote: %v", err)
+	}
+	if quote.Price != 11.5 {
+		t.Fatalf("Price = %v, want 11.5", quote.Price)
+	}
+}
+
+func TestCoinGeckoProviderRetriesOn429(t *testing.T) {
+	attempts := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.Write([]byte(`{"ethereum":{"usd":1500}}`))
+	}))
+	defer srv.Close()
+
+	p := newTestCoinGeckoProvider(t, srv)
+	quote, err := p.FetchQuote("ethereum")
+	if err != nil {
+		t.Fatalf("FetchQuote: %v", err)
+	}
+	if quote.Price != 1500 {
+		t.Fatalf("Price = %v, want 1500", quote.Price)
+	}
+	if attempts < 2 {
+		t.Fatalf("attempts = %d, want at least 2 (one 429 then a retry)", attempts)
+	}
+}
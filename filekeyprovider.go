@@ -0,0 +1,146 @@
+package go_arkham_oracle_sdk
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+// fileKeyEntry is one signing key as stored in a FileKeyProvider's JSON file.
+type fileKeyEntry struct {
+	KeyID      string    `json:"keyId"`
+	PrivateKey string    `json:"privateKey"` // hex-encoded ed25519 private key
+	ExpiresAt  time.Time `json:"expiresAt,omitempty"`
+}
+
+type fileKeyDocument struct {
+	CurrentKeyID string         `json:"currentKeyId"`
+	Keys         []fileKeyEntry `json:"keys"`
+}
+
+// FileKeyProvider is a KeyProvider that loads signing keys from a JSON file
+// and reloads it on change via fsnotify, so keys can be rotated by writing a
+// new file rather than restarting the process.
+//
+// File format:
+//
+//	{
+//	  "currentKeyId": "2026-08",
+//	  "keys": [
+//	    {"keyId": "2026-07", "privateKey": "<hex>", "expiresAt": "2026-08-01T00:00:00Z"},
+//	    {"keyId": "2026-08", "privateKey": "<hex>"}
+//	  ]
+//	}
+type FileKeyProvider struct {
+	Path string
+
+	mu      sync.RWMutex
+	current fileKeyEntry
+	signer  ed25519.PrivateKey
+	watcher *fsnotify.Watcher
+}
+
+// NewFileKeyProvider loads Path and starts watching it for changes.
+func NewFileKeyProvider(path string) (*FileKeyProvider, error) {
+	p := &FileKeyProvider{Path: path}
+	if err := p.reload(); err != nil {
+		return nil, err
+	}
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return nil, fmt.Errorf("file key provider: failed to create watcher: %w", err)
+	}
+	if err := watcher.Add(path); err != nil {
+		watcher.Close()
+		return nil, fmt.Errorf("file key provider: failed to watch %s: %w", path, err)
+	}
+	p.watcher = watcher
+
+	go p.watch()
+	return p, nil
+}
+
+func (p *FileKeyProvider) watch() {
+	for {
+		select {
+		case event, ok := <-p.watcher.Events:
+			if !ok {
+				return
+			}
+			if event.Op&(fsnotify.Write|fsnotify.Create) == 0 {
+				continue
+			}
+			if err := p.reload(); err != nil {
+				log.Printf("file key provider: failed to reload %s: %v", p.Path, err)
+			}
+		case err, ok := <-p.watcher.Errors:
+			if !ok {
+				return
+			}
+			log.Printf("file key provider: watcher error: %v", err)
+		}
+	}
+}
+
+func (p *FileKeyProvider) reload() error {
+	raw, err := os.ReadFile(p.Path)
+	if err != nil {
+		return fmt.Errorf("file key provider: failed to read %s: %w", p.Path, err)
+	}
+
+	var doc fileKeyDocument
+	if err := json.Unmarshal(raw, &doc); err != nil {
+		return fmt.Errorf("file key provider: failed to parse %s: %w", p.Path, err)
+	}
+
+	var current *fileKeyEntry
+	for i := range doc.Keys {
+		if doc.Keys[i].KeyID == doc.CurrentKeyID {
+			current = &doc.Keys[i]
+			break
+		}
+	}
+	if current == nil {
+		return fmt.Errorf("file key provider: currentKeyId %q not found in %s", doc.CurrentKeyID, p.Path)
+	}
+
+	keyBytes, err := hex.DecodeString(current.PrivateKey)
+	if err != nil {
+		return fmt.Errorf("file key provider: failed to decode key %q: %w", current.KeyID, err)
+	}
+	if len(keyBytes) != ed25519.PrivateKeySize {
+		return fmt.Errorf("file key provider: key %q has invalid size %d", current.KeyID, len(keyBytes))
+	}
+
+	p.mu.Lock()
+	p.current = *current
+	p.signer = ed25519.PrivateKey(keyBytes)
+	p.mu.Unlock()
+	return nil
+}
+
+func (p *FileKeyProvider) CurrentKey() (string, crypto.Signer, time.Time, error) {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+	if p.signer == nil {
+		return "", nil, time.Time{}, fmt.Errorf("file key provider: no key loaded")
+	}
+	return p.current.KeyID, p.signer, p.current.ExpiresAt, nil
+}
+
+// Close stops watching the key file.
+func (p *FileKeyProvider) Close() error {
+	if p.watcher == nil {
+		return nil
+	}
+	return p.watcher.Close()
+}
@@ -0,0 +1,42 @@
+package go_arkham_oracle_sdk
+
+import (
+	"crypto"
+	"fmt"
+	"time"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// KeyProvider supplies the signing key used for each request, so keys can be
+// rotated on a schedule without restarting the oracle process.
+type KeyProvider interface {
+	// CurrentKey returns the active key's ID, a crypto.Signer that can
+	// produce an Ed25519 signature over a raw message (opts must be
+	// crypto.Hash(0)), and the key's expiry (zero means it never expires).
+	CurrentKey() (keyID string, signer crypto.Signer, expiresAt time.Time, err error)
+}
+
+// keyIDDigest returns the first 8 bytes of keccak256(keyID), prepended to the
+// signed pre-image so a signature produced under a stale key can't be
+// replayed as if it came from a newer key with the same index.
+func keyIDDigest(keyID string) []byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write([]byte(keyID))
+	return hasher.Sum(nil)[:8]
+}
+
+// StaticKeyProvider is a KeyProvider over a single, never-rotating key. It
+// preserves the SDK's original fixed-key behavior.
+type StaticKeyProvider struct {
+	KeyID     string
+	Signer    crypto.Signer
+	ExpiresAt time.Time // zero means no expiry
+}
+
+func (p *StaticKeyProvider) CurrentKey() (string, crypto.Signer, time.Time, error) {
+	if p.Signer == nil {
+		return "", nil, time.Time{}, fmt.Errorf("static key provider: no signer configured")
+	}
+	return p.KeyID, p.Signer, p.ExpiresAt, nil
+}
@@ -0,0 +1,132 @@
+package go_arkham_oracle_sdk
+
+import (
+	"crypto/ed25519"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestKeyIDDigestIsDeterministicAnd8Bytes(t *testing.T) {
+	a := keyIDDigest("2026-07")
+	b := keyIDDigest("2026-07")
+	c := keyIDDigest("2026-08")
+
+	if len(a) != 8 {
+		t.Fatalf("len(keyIDDigest()) = %d, want 8", len(a))
+	}
+	if string(a) != string(b) {
+		t.Fatalf("keyIDDigest() is not deterministic for the same keyID")
+	}
+	if string(a) == string(c) {
+		t.Fatalf("keyIDDigest() collided for distinct keyIDs")
+	}
+}
+
+func TestStaticKeyProvider(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	expires := time.Now().Add(time.Hour)
+	p := &StaticKeyProvider{KeyID: "k1", Signer: priv, ExpiresAt: expires}
+
+	keyID, signer, expiresAt, err := p.CurrentKey()
+	if err != nil {
+		t.Fatalf("CurrentKey: %v", err)
+	}
+	gotPriv, ok := signer.(ed25519.PrivateKey)
+	if keyID != "k1" || !ok || string(gotPriv) != string(priv) || !expiresAt.Equal(expires) {
+		t.Fatalf("CurrentKey() = (%q, _, %v), want (k1, priv, %v)", keyID, expiresAt, expires)
+	}
+}
+
+func TestStaticKeyProviderRequiresSigner(t *testing.T) {
+	p := &StaticKeyProvider{KeyID: "k1"}
+	if _, _, _, err := p.CurrentKey(); err == nil {
+		t.Fatalf("CurrentKey() with no Signer should error")
+	}
+}
+
+func writeKeyFile(t *testing.T, path, currentKeyID string, entries []fileKeyEntry) {
+	t.Helper()
+	doc := fileKeyDocument{CurrentKeyID: currentKeyID, Keys: entries}
+	raw, err := json.Marshal(doc)
+	if err != nil {
+		t.Fatalf("Marshal: %v", err)
+	}
+	if err := os.WriteFile(path, raw, 0600); err != nil {
+		t.Fatalf("WriteFile: %v", err)
+	}
+}
+
+func TestFileKeyProviderLoadsCurrentKey(t *testing.T) {
+	_, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	path := filepath.Join(t.TempDir(), "keys.json")
+	writeKeyFile(t, path, "k1", []fileKeyEntry{{KeyID: "k1", PrivateKey: hex.EncodeToString(priv)}})
+
+	p, err := NewFileKeyProvider(path)
+	if err != nil {
+		t.Fatalf("NewFileKeyProvider: %v", err)
+	}
+	defer p.Close()
+
+	keyID, _, _, err := p.CurrentKey()
+	if err != nil {
+		t.Fatalf("CurrentKey: %v", err)
+	}
+	if keyID != "k1" {
+		t.Fatalf("keyID = %q, want k1", keyID)
+	}
+}
+
+func TestFileKeyProviderReloadsOnWrite(t *testing.T) {
+	_, priv1, _ := ed25519.GenerateKey(nil)
+	_, priv2, _ := ed25519.GenerateKey(nil)
+	path := filepath.Join(t.TempDir(), "keys.json")
+	writeKeyFile(t, path, "k1", []fileKeyEntry{{KeyID: "k1", PrivateKey: hex.EncodeToString(priv1)}})
+
+	p, err := NewFileKeyProvider(path)
+	if err != nil {
+		t.Fatalf("NewFileKeyProvider: %v", err)
+	}
+	defer p.Close()
+
+	writeKeyFile(t, path, "k2", []fileKeyEntry{
+		{KeyID: "k1", PrivateKey: hex.EncodeToString(priv1)},
+		{KeyID: "k2", PrivateKey: hex.EncodeToString(priv2)},
+	})
+
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		if keyID, _, _, _ := p.CurrentKey(); keyID == "k2" {
+			return
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	t.Fatalf("FileKeyProvider did not pick up the rotated key within the deadline")
+}
+
+func TestFileKeyProviderRejectsUnknownCurrentKeyID(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	writeKeyFile(t, path, "missing", nil)
+
+	if _, err := NewFileKeyProvider(path); err == nil {
+		t.Fatalf("NewFileKeyProvider() should error when currentKeyId isn't in keys")
+	}
+}
+
+func TestFileKeyProviderRejectsBadKeySize(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "keys.json")
+	writeKeyFile(t, path, "k1", []fileKeyEntry{{KeyID: "k1", PrivateKey: hex.EncodeToString([]byte("too-short"))}})
+
+	if _, err := NewFileKeyProvider(path); err == nil {
+		t.Fatalf("NewFileKeyProvider() should error on a private key of the wrong size")
+	}
+}
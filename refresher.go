@@ -0,0 +1,51 @@
+package go_arkham_oracle_sdk
+
+import (
+	"context"
+	"log"
+	"time"
+)
+
+// CacheRefresher periodically pre-warms a CachedSource's cache for a fixed
+// set of "hot" tokens, so request latency doesn't depend on a cold cache miss
+// against upstream.
+type CacheRefresher struct {
+	// Sources are refreshed on every tick; typically the same CachedSource
+	// instances passed to the aggregator.
+	Sources []PriceSource
+	// Tokens are the hot tokens to pre-warm on every tick.
+	Tokens []string
+	// Interval between refresh ticks. Defaults to 30 seconds.
+	Interval time.Duration
+}
+
+// Start begins refreshing in the background until ctx is canceled.
+func (r *CacheRefresher) Start(ctx context.Context) {
+	interval := r.Interval
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				r.refreshOnce()
+			}
+		}
+	}()
+}
+
+func (r *CacheRefresher) refreshOnce() {
+	for _, token := range r.Tokens {
+		for _, src := range r.Sources {
+			if _, err := src.FetchQuote(token); err != nil {
+				log.Printf("cache refresher: failed to pre-warm %s from %s: %v", token, src.Name(), err)
+			}
+		}
+	}
+}
@@ -0,0 +1,38 @@
+package go_arkham_oracle_sdk
+
+import (
+	"crypto"
+	"fmt"
+	"time"
+)
+
+// KMSKeyProvider is a KeyProvider backed by an AWS KMS asymmetric signing
+// key, so the private key material never needs to leave KMS. This is a stub:
+// wire Sign up to kms.Client.Sign with SigningAlgorithm
+// ECDSA_SHA_256/EDDSA as appropriate for KeyID, then return a crypto.Signer
+// that calls it.
+type KMSKeyProvider struct {
+	// KeyID is the AWS KMS key ID or ARN.
+	KeyID string
+	// Region is the AWS region the key lives in.
+	Region string
+}
+
+func (p *KMSKeyProvider) CurrentKey() (string, crypto.Signer, time.Time, error) {
+	return "", nil, time.Time{}, fmt.Errorf("kms key provider: not implemented, wire up github.com/aws/aws-sdk-go-v2/service/kms")
+}
+
+// VaultKeyProvider is a KeyProvider backed by HashiCorp Vault's Transit
+// secrets engine, so the private key material never needs to leave Vault.
+// This is a stub: wire Sign up to the Transit engine's /sign endpoint for
+// KeyName, then return a crypto.Signer that calls it.
+type VaultKeyProvider struct {
+	// Address is the Vault server address.
+	Address string
+	// KeyName is the Transit engine key name.
+	KeyName string
+}
+
+func (p *VaultKeyProvider) CurrentKey() (string, crypto.Signer, time.Time, error) {
+	return "", nil, time.Time{}, fmt.Errorf("vault key provider: not implemented, wire up github.com/hashicorp/vault/api transit engine")
+}
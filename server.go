@@ -2,33 +2,107 @@ package go_arkham_oracle_sdk
 
 import (
 	"bytes"
-	"crypto/ed25519"
+	"crypto"
+	"crypto/rand"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
+	"strconv"
+	"sync"
 	"time"
 
+	"github.com/ethereum/go-ethereum/common"
 	"golang.org/x/crypto/sha3"
 )
 
 // OracleHandlerOptions configures the oracle handler.
 type OracleHandlerOptions struct {
-	// The 64-byte Ed25519 private key used for signing price data.
-	OraclePrivateKey ed25519.PrivateKey
-	// Optional. An array of strings to use as API keys for authorization.
-	// If this slice is nil or empty, the endpoint will be public.
+	// KeyProvider supplies the Ed25519 signing key on each request, enabling
+	// scheduled rotation without a restart. Required when SigningScheme is
+	// SchemeEd25519Keccak (the default). For a single never-rotating key,
+	// use &StaticKeyProvider{Signer: privKey}.
+	KeyProvider KeyProvider
+	// Optional. An array of strings to use as API keys for authorization via
+	// the original ?trustedClientKey= query parameter. Deprecated: query
+	// parameters leak into proxy logs and allow replay; prefer AuthPolicy.
+	// Ignored once AuthPolicy is set. If both are nil, the endpoint is public.
 	TrustedClientKeys []string
+	// Optional. AuthPolicy authorizes each request, taking priority over
+	// TrustedClientKeys when set. Use &HMACAuthPolicy{...} for HMAC-signed
+	// requests with replay protection and per-key rate limiting.
+	AuthPolicy AuthPolicy
 	// Optional. A URL for an alternative data source to fetch prices from.
-	// If empty, CoinGecko will be used by default.
+	// If empty, CoinGecko will be used by default. Ignored once Sources is set.
 	DataSourceURL string
+	// Optional. A CoinGecko Pro API key. If set, the default CoinGecko source
+	// sends it as X-Cg-Pro-Api-Key and uses pro-api.coingecko.com. Ignored
+	// once Sources is set.
+	CoinGeckoAPIKey string
+	// Optional. Overrides for symbol-to-CoinGecko-ID resolution on the default
+	// CoinGecko source, e.g. {"ETH": "ethereum"}. Ignored once Sources is set.
+	CoinGeckoSymbolMap map[string]string
+
+	// Sources lists the PriceSource adapters to aggregate over. If empty, the
+	// handler falls back to a single source: a CoinGeckoProvider, or, if
+	// DataSourceURL is set, the original hard-coded data source behavior.
+	Sources []PriceSource
+	// Strategy selects how quotes from Sources are combined. Defaults to
+	// StrategyMedian.
+	Strategy AggregationStrategy
+	// MaxDeviationBps discards any sample whose deviation from the median
+	// exceeds this many basis points before aggregation. Zero disables
+	// outlier rejection.
+	MaxDeviationBps uint32
+	// MinSources is the minimum number of surviving samples required before a
+	// price can be signed. Requests that don't meet this bar get a 502 with
+	// an "insufficient_confidence" error. Defaults to 1.
+	MinSources int
+	// RollingWindow bounds how far back a quote may be and still count towards
+	// aggregation. Defaults to 1 minute.
+	RollingWindow time.Duration
+
+	// SigningScheme selects how price reports are signed. Defaults to
+	// SchemeEd25519Keccak, in which case KeyProvider is required.
+	SigningScheme SigningScheme
+	// EVM configures EIP-712 typed-data signing. Required when SigningScheme
+	// is SchemeEVMSecp256k1; ignored otherwise.
+	EVM *EVMSigningConfig
+
+	// Cache, if set, is consulted before each configured source is hit and
+	// populated after, wrapping every source in a CachedSource.
+	Cache PriceCache
+	// MaxStaleness bounds how old a cached entry may be and still be reused.
+	// Ignored if Cache is nil.
+	MaxStaleness time.Duration
+	// NegativeTTL bounds how long a "token not found" result is remembered
+	// before the upstream source is retried. Ignored if Cache is nil.
+	NegativeTTL time.Duration
 }
 
 // NewOracleHandler creates a new http.HandlerFunc that acts as a verifiable oracle.
 func NewOracleHandler(options OracleHandlerOptions) (http.HandlerFunc, error) {
-	if len(options.OraclePrivateKey) != ed25519.PrivateKeySize {
-		return nil, fmt.Errorf("invalid private key size: expected %d, got %d", ed25519.PrivateKeySize, len(options.OraclePrivateKey))
+	scheme := options.SigningScheme
+	if scheme == "" {
+		scheme = SchemeEd25519Keccak
+	}
+
+	switch scheme {
+	case SchemeEd25519Keccak:
+		if options.KeyProvider == nil {
+			return nil, fmt.Errorf("signing scheme %q requires options.KeyProvider", scheme)
+		}
+	case SchemeEVMSecp256k1:
+		if options.EVM == nil || options.EVM.PrivateKey == nil {
+			return nil, fmt.Errorf("evm signing scheme requires options.EVM.PrivateKey")
+		}
+		if options.EVM.ChainID == nil {
+			return nil, fmt.Errorf("evm signing scheme requires options.EVM.ChainID")
+		}
+	default:
+		return nil, fmt.Errorf("unsupported signing scheme: %q", scheme)
 	}
 
 	// Create a map for quick lookup of trusted keys
@@ -37,10 +111,51 @@ func NewOracleHandler(options OracleHandlerOptions) (http.HandlerFunc, error) {
 		trustedKeysMap[key] = true
 	}
 
+	sources := append([]PriceSource(nil), options.Sources...)
+	if len(sources) == 0 {
+		if options.DataSourceURL != "" {
+			sources = []PriceSource{&defaultCoinGeckoSource{dataSourceURL: options.DataSourceURL}}
+		} else {
+			sources = []PriceSource{&CoinGeckoProvider{
+				APIKey:    options.CoinGeckoAPIKey,
+				SymbolMap: options.CoinGeckoSymbolMap,
+			}}
+		}
+	}
+
+	if options.Cache != nil {
+		for i, src := range sources {
+			sources[i] = &CachedSource{
+				Inner:        src,
+				Cache:        options.Cache,
+				MaxStaleness: options.MaxStaleness,
+				NegativeTTL:  options.NegativeTTL,
+			}
+		}
+	}
+
+	aggregator := NewAggregator(AggregatorOptions{
+		Strategy:        options.Strategy,
+		Window:          options.RollingWindow,
+		MaxDeviationBps: options.MaxDeviationBps,
+		MinSources:      options.MinSources,
+	})
+
 	// This is the actual handler function that will be returned
 	handler := func(w http.ResponseWriter, r *http.Request) {
 		// 1. Security Validation (Optional)
-		if len(trustedKeysMap) > 0 {
+		if options.AuthPolicy != nil {
+			result := options.AuthPolicy.Authorize(r)
+			if !result.Allowed {
+				if result.Reason == "rate_limited" {
+					w.Header().Set("Retry-After", strconv.Itoa(int(result.RetryAfter.Seconds())))
+					http.Error(w, `{"error":"rate_limited"}`, http.StatusTooManyRequests)
+				} else {
+					http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
+				}
+				return
+			}
+		} else if len(trustedKeysMap) > 0 {
 			clientKey := r.URL.Query().Get("trustedClientKey")
 			if !trustedKeysMap[clientKey] {
 				http.Error(w, `{"error":"Unauthorized"}`, http.StatusUnauthorized)
@@ -54,59 +169,114 @@ func NewOracleHandler(options OracleHandlerOptions) (http.HandlerFunc, error) {
 			return
 		}
 
-		// 2. Fetch Price from Data Source
-		dataSource := "https://api.coingecko.com/api/v3/simple/price"
-		if options.DataSourceURL != "" {
-			dataSource = options.DataSourceURL
-		}
-		priceURL := fmt.Sprintf("%s?ids=%s&vs_currencies=usd", dataSource, token)
+		// 2. Fetch quotes from every configured source concurrently and feed
+		// them into the rolling aggregator.
+		fetchQuotes(sources, token, aggregator)
 
-		priceResp, err := http.Get(priceURL)
-		if err != nil {
-			log.Printf("Error fetching from data source: %v", err)
-			http.Error(w, `{"error":"Failed to fetch price data"}`, http.StatusInternalServerError)
-			return
-		}
-		defer priceResp.Body.Close()
-
-		var priceData CoinGeckoPriceResponse
-		if err := json.NewDecoder(priceResp.Body).Decode(&priceData); err != nil {
-			log.Printf("Error decoding price data: %v", err)
-			http.Error(w, `{"error":"Failed to decode price data"}`, http.StatusInternalServerError)
+		priceFloat, sourceCount, err := aggregator.Aggregate(token)
+		if errors.Is(err, ErrInsufficientConfidence) {
+			http.Error(w, `{"error":"insufficient_confidence"}`, http.StatusBadGateway)
 			return
 		}
-
-		priceFloat := priceData[token].Usd
-		if priceFloat == 0 {
-			http.Error(w, fmt.Sprintf(`{"error":"Price for token '%s' not found"}`, token), http.StatusNotFound)
+		if err != nil {
+			log.Printf("Error aggregating price data: %v", err)
+			http.Error(w, `{"error":"Failed to fetch price data"}`, http.StatusInternalServerError)
 			return
 		}
 
 		// 3. Prepare Data for Signing
 		priceU64 := uint64(priceFloat * 1_000_000)
 		timestampI64 := time.Now().Unix()
+		method := string(aggregator.Strategy())
 
-		buf := new(bytes.Buffer)
-		binary.Write(buf, binary.LittleEndian, priceU64)
-		binary.Write(buf, binary.LittleEndian, timestampI64)
-		
-		hasher := sha3.NewLegacyKeccak256()
-		hasher.Write(buf.Bytes())
-		messageHash := hasher.Sum(nil)
+		responsePayload := SignedPriceData{
+			Price:       priceU64,
+			Timestamp:   timestampI64,
+			Method:      method,
+			SourceCount: sourceCount,
+			Scheme:      string(scheme),
+		}
 
 		// 4. Sign the Message Hash
-		signature := ed25519.Sign(options.OraclePrivateKey, messageHash)
+		switch scheme {
+		case SchemeEVMSecp256k1:
+			if !common.IsHexAddress(token) {
+				http.Error(w, `{"error":"token must be a hex address under the evm signing scheme"}`, http.StatusBadRequest)
+				return
+			}
+			tokenAddr := common.HexToAddress(token)
+			signature, err := signEIP712PriceReport(options.EVM, tokenAddr, priceU64, uint64(timestampI64))
+			if err != nil {
+				log.Printf("Error signing price data: %v", err)
+				http.Error(w, `{"error":"Failed to sign price data"}`, http.StatusInternalServerError)
+				return
+			}
+			responsePayload.Signature = signature
+			responsePayload.TokenAddress = tokenAddr.Hex()
+			responsePayload.ChainID = options.EVM.ChainID.String()
+			responsePayload.VerifyingContract = options.EVM.VerifyingContract.Hex()
+		default:
+			keyID, signer, expiresAt, err := options.KeyProvider.CurrentKey()
+			if err != nil {
+				log.Printf("Error resolving signing key: %v", err)
+				http.Error(w, `{"error":"Failed to resolve signing key"}`, http.StatusInternalServerError)
+				return
+			}
+			if !expiresAt.IsZero() && time.Now().After(expiresAt) {
+				log.Printf("Error: signing key %q has expired", keyID)
+				http.Error(w, `{"error":"Signing key expired"}`, http.StatusInternalServerError)
+				return
+			}
 
-		// 5. Return the Data
-		responsePayload := SignedPriceData{
-			Price:     priceU64,
-			Timestamp: timestampI64,
-			Signature: signature,
+			buf := new(bytes.Buffer)
+			buf.Write(keyIDDigest(keyID))
+			binary.Write(buf, binary.LittleEndian, priceU64)
+			binary.Write(buf, binary.LittleEndian, timestampI64)
+			buf.WriteByte(aggregator.Strategy().methodID())
+			buf.WriteByte(byte(sourceCount))
+
+			hasher := sha3.NewLegacyKeccak256()
+			hasher.Write(buf.Bytes())
+			messageHash := hasher.Sum(nil)
+
+			signature, err := signer.Sign(rand.Reader, messageHash, crypto.Hash(0))
+			if err != nil {
+				log.Printf("Error signing price data: %v", err)
+				http.Error(w, `{"error":"Failed to sign price data"}`, http.StatusInternalServerError)
+				return
+			}
+
+			responsePayload.Signature = signature
+			responsePayload.KeyID = keyID
+			if !expiresAt.IsZero() {
+				responsePayload.KeyExpiresAt = expiresAt.UTC().Format(time.RFC3339)
+			}
 		}
 
+		// 5. Return the Data
 		w.Header().Set("Content-Type", "application/json")
 		json.NewEncoder(w).Encode(responsePayload)
 	}
 
 	return handler, nil
 }
+
+// fetchQuotes queries every source concurrently and ingests whatever
+// successfully returns into the aggregator, logging (but not failing on)
+// individual source errors so a single down feed can't take out the oracle.
+func fetchQuotes(sources []PriceSource, token string, aggregator *Aggregator) {
+	var wg sync.WaitGroup
+	for _, src := range sources {
+		wg.Add(1)
+		go func(src PriceSource) {
+			defer wg.Done()
+			quote, err := src.FetchQuote(token)
+			if err != nil {
+				log.Printf("Error fetching from source %s: %v", src.Name(), err)
+				return
+			}
+			aggregator.Ingest(quote)
+		}(src)
+	}
+	wg.Wait()
+}
@@ -0,0 +1,160 @@
+package go_arkham_oracle_sdk
+
+import (
+	"crypto/ecdsa"
+	"fmt"
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// SigningScheme selects how a price report is signed and hashed for
+// verification.
+type SigningScheme string
+
+const (
+	// SchemeEd25519Keccak is the SDK's original scheme: an Ed25519 signature
+	// over a little-endian Keccak-256 hash of (8-byte key-id digest, price,
+	// timestamp, method, sourceCount). This is the default when SigningScheme
+	// is unset.
+	SchemeEd25519Keccak SigningScheme = "ed25519-keccak256"
+	// SchemeEVMSecp256k1 produces a 65-byte [R || S || V] secp256k1 signature
+	// over an EIP-712 typed-data digest, recoverable on-chain via ecrecover.
+	SchemeEVMSecp256k1 SigningScheme = "evm-secp256k1-eip712"
+)
+
+// defaultDomainName and defaultDomainVersion are used for the EIP-712 domain
+// separator when EVMSigningConfig leaves them unset.
+const (
+	defaultDomainName    = "ArkhamOracle"
+	defaultDomainVersion = "1"
+)
+
+// priceReportTypeHash is keccak256 of the PriceReport EIP-712 type string.
+// chainId and verifyingContract are deliberately absent from the message
+// type: they're already bound into the digest via the domain separator, and
+// repeating them here would leave a mismatch for any verifier (on-chain or
+// off) that derives its struct hash from this declared type string instead
+// of hand-rolling the encoding.
+var priceReportTypeHash = crypto.Keccak256Hash(
+	[]byte("PriceReport(address token,uint64 price,uint64 timestamp)"),
+)
+
+// eip712DomainTypeHash is keccak256 of the EIP712Domain type string.
+var eip712DomainTypeHash = crypto.Keccak256Hash(
+	[]byte("EIP712Domain(string name,string version,uint256 chainId,address verifyingContract)"),
+)
+
+// EVMSigningConfig configures EIP-712 typed-data signing for EVM verifier
+// compatibility, used when OracleHandlerOptions.SigningScheme is
+// SchemeEVMSecp256k1.
+type EVMSigningConfig struct {
+	// PrivateKey signs the EIP-712 digest.
+	PrivateKey *ecdsa.PrivateKey
+	// ChainID is the EIP-712 domain's chainId.
+	ChainID *big.Int
+	// VerifyingContract is the EIP-712 domain's verifyingContract.
+	VerifyingContract common.Address
+	// DomainName defaults to "ArkhamOracle" if empty.
+	DomainName string
+	// DomainVersion defaults to "1" if empty.
+	DomainVersion string
+}
+
+func (c *EVMSigningConfig) domainName() string {
+	if c.DomainName != "" {
+		return c.DomainName
+	}
+	return defaultDomainName
+}
+
+func (c *EVMSigningConfig) domainVersion() string {
+	if c.DomainVersion != "" {
+		return c.DomainVersion
+	}
+	return defaultDomainVersion
+}
+
+// uint256Word left-pads v into a 32-byte big-endian EVM word.
+func uint256Word(v *big.Int) [32]byte {
+	var word [32]byte
+	v.FillBytes(word[:])
+	return word
+}
+
+// addressWord left-pads an address into a 32-byte EVM word.
+func addressWord(addr common.Address) [32]byte {
+	var word [32]byte
+	copy(word[12:], addr.Bytes())
+	return word
+}
+
+// domainSeparator computes the EIP-712 domain separator:
+// keccak256(abi.encode(typeHash, keccak256(name), keccak256(version), chainId, verifyingContract)).
+func (c *EVMSigningConfig) domainSeparator() common.Hash {
+	nameHash := crypto.Keccak256Hash([]byte(c.domainName()))
+	versionHash := crypto.Keccak256Hash([]byte(c.domainVersion()))
+	chainIDWord := uint256Word(c.ChainID)
+	contractWord := addressWord(c.VerifyingContract)
+
+	buf := make([]byte, 0, 32*5)
+	buf = append(buf, eip712DomainTypeHash.Bytes()...)
+	buf = append(buf, nameHash.Bytes()...)
+	buf = append(buf, versionHash.Bytes()...)
+	buf = append(buf, chainIDWord[:]...)
+	buf = append(buf, contractWord[:]...)
+	return crypto.Keccak256Hash(buf)
+}
+
+// priceReportStructHash computes keccak256(abi.encode(PriceReportTypeHash, tokenAddr, price, timestamp)).
+func priceReportStructHash(token common.Address, price, timestamp uint64) common.Hash {
+	priceWord := uint256Word(new(big.Int).SetUint64(price))
+	timestampWord := uint256Word(new(big.Int).SetUint64(timestamp))
+	tokenWord := addressWord(token)
+
+	buf := make([]byte, 0, 32*4)
+	buf = append(buf, priceReportTypeHash.Bytes()...)
+	buf = append(buf, tokenWord[:]...)
+	buf = append(buf, priceWord[:]...)
+	buf = append(buf, timestampWord[:]...)
+	return crypto.Keccak256Hash(buf)
+}
+
+// eip712Digest computes the final EIP-712 digest: keccak256(0x1901 || domainSeparator || structHash).
+func eip712Digest(domainSeparator, structHash common.Hash) common.Hash {
+	buf := make([]byte, 0, 2+32+32)
+	buf = append(buf, 0x19, 0x01)
+	buf = append(buf, domainSeparator.Bytes()...)
+	buf = append(buf, structHash.Bytes()...)
+	return crypto.Keccak256Hash(buf)
+}
+
+// signEIP712PriceReport signs (token, price, timestamp) under cfg's EIP-712
+// domain, returning a 65-byte [R || S || V] signature with V adjusted to
+// 27/28 for direct ecrecover compatibility in Solidity.
+func signEIP712PriceReport(cfg *EVMSigningConfig, token common.Address, price, timestamp uint64) ([]byte, error) {
+	if cfg == nil || cfg.PrivateKey == nil {
+		return nil, fmt.Errorf("evm signing: no EVMSigningConfig.PrivateKey configured")
+	}
+	digest := eip712Digest(cfg.domainSeparator(), priceReportStructHash(token, price, timestamp))
+
+	sig, err := crypto.Sign(digest.Bytes(), cfg.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("evm signing: failed to sign digest: %w", err)
+	}
+	sig[64] += 27 // adjust recovery id from 0/1 to the Ethereum-standard 27/28
+	return sig, nil
+}
+
+// reconstructEIP712Digest mirrors eip712Digest for verifiers that only have
+// the signed response, not the original domain config.
+func reconstructEIP712Digest(domainName, domainVersion string, chainID *big.Int, verifyingContract, token common.Address, price, timestamp uint64) []byte {
+	cfg := &EVMSigningConfig{
+		ChainID:           chainID,
+		VerifyingContract: verifyingContract,
+		DomainName:        domainName,
+		DomainVersion:     domainVersion,
+	}
+	return eip712Digest(cfg.domainSeparator(), priceReportStructHash(token, price, timestamp)).Bytes()
+}
@@ -0,0 +1,82 @@
+package go_arkham_oracle_sdk
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// TestEIP712DigestMatchesDeclaredTypeString pins priceReportTypeHash against
+// the declared PriceReport type string, and checks that
+// reconstructEIP712Digest yields the same digest as eip712Digest over the
+// same domain separator and struct hash, so a future edit that changes the
+// declared type (like the chainId/verifyingContract bug fixed alongside this
+// test) is caught here instead of only at ecrecover time on-chain.
+func TestEIP712DigestMatchesDeclaredTypeString(t *testing.T) {
+	cfg := &EVMSigningConfig{
+		ChainID:           big.NewInt(1),
+		VerifyingContract: common.HexToAddress("0x00000000000000000000000000000000000001"),
+		DomainName:        "ArkhamOracle",
+		DomainVersion:     "1",
+	}
+	token := common.HexToAddress("0x00000000000000000000000000000000000002")
+	const price, timestamp uint64 = 1_000_000, 1_700_000_000
+
+	wantTypeHash := crypto.Keccak256Hash([]byte("PriceReport(address token,uint64 price,uint64 timestamp)"))
+	if priceReportTypeHash != wantTypeHash {
+		t.Fatalf("priceReportTypeHash = %x, want %x (declared type string must match struct hash fields)", priceReportTypeHash, wantTypeHash)
+	}
+
+	structHash := priceReportStructHash(token, price, timestamp)
+	wantDigest := eip712Digest(cfg.domainSeparator(), structHash)
+
+	gotDigest := reconstructEIP712Digest(cfg.DomainName, cfg.DomainVersion, cfg.ChainID, cfg.VerifyingContract, token, price, timestamp)
+	if common.BytesToHash(gotDigest) != wantDigest {
+		t.Fatalf("reconstructEIP712Digest = %x, want %x", gotDigest, wantDigest)
+	}
+}
+
+// TestSignEIP712PriceReportRecoversSigner signs a price report and checks
+// that ecrecover-equivalent signature recovery (via crypto.SigToPub) yields
+// back the signing key's address, over the exact digest a verifier would
+// reconstruct from the response fields alone.
+func TestSignEIP712PriceReportRecoversSigner(t *testing.T) {
+	priv, err := crypto.GenerateKey()
+	if err != nil {
+		t.Fatalf("GenerateKey: %v", err)
+	}
+	wantAddr := crypto.PubkeyToAddress(priv.PublicKey)
+
+	cfg := &EVMSigningConfig{
+		PrivateKey:        priv,
+		ChainID:           big.NewInt(1),
+		VerifyingContract: common.HexToAddress("0x00000000000000000000000000000000000001"),
+	}
+	token := common.HexToAddress("0x00000000000000000000000000000000000002")
+	const price, timestamp uint64 = 2_500_000, 1_700_000_100
+
+	sig, err := signEIP712PriceReport(cfg, token, price, timestamp)
+	if err != nil {
+		t.Fatalf("signEIP712PriceReport: %v", err)
+	}
+	if len(sig) != 65 {
+		t.Fatalf("signature length = %d, want 65", len(sig))
+	}
+
+	digest := reconstructEIP712Digest(cfg.domainName(), cfg.domainVersion(), cfg.ChainID, cfg.VerifyingContract, token, price, timestamp)
+
+	// crypto.Sign/SigToPub expect the 27/28-adjusted V rolled back to 0/1.
+	recoverSig := make([]byte, 65)
+	copy(recoverSig, sig)
+	recoverSig[64] -= 27
+
+	pub, err := crypto.SigToPub(digest, recoverSig)
+	if err != nil {
+		t.Fatalf("SigToPub: %v", err)
+	}
+	if gotAddr := crypto.PubkeyToAddress(*pub); gotAddr != wantAddr {
+		t.Fatalf("recovered signer = %s, want %s", gotAddr.Hex(), wantAddr.Hex())
+	}
+}
@@ -0,0 +1,282 @@
+package go_arkham_oracle_sdk
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+)
+
+// Quote is a single price observation returned by a PriceSource.
+type Quote struct {
+	Source    string
+	Token     string
+	Price     float64
+	Volume    float64 // optional; used by volume-weighted strategies, zero if unknown
+	Timestamp time.Time
+}
+
+// PriceSource is implemented by anything that can fetch a price quote for a token.
+// Adapters for CoinGecko, Binance, Coinbase, Kraken, on-chain Chainlink feeds, and
+// arbitrary custom HTTP endpoints all satisfy this interface so they can be mixed
+// and matched behind the aggregator.
+type PriceSource interface {
+	// Name identifies the source, e.g. "coingecko" or "binance". Used in logs and
+	// to key cache entries.
+	Name() string
+	// FetchQuote retrieves the current price for token from this source.
+	FetchQuote(token string) (Quote, error)
+}
+
+// defaultCoinGeckoSource is the built-in fallback source used when no Sources are
+// configured, preserving the SDK's original single-source behavior.
+type defaultCoinGeckoSource struct {
+	dataSourceURL string
+}
+
+func (s *defaultCoinGeckoSource) Name() string {
+	return "coingecko"
+}
+
+func (s *defaultCoinGeckoSource) FetchQuote(token string) (Quote, error) {
+	dataSource := "https://api.coingecko.com/api/v3/simple/price"
+	if s.dataSourceURL != "" {
+		dataSource = s.dataSourceURL
+	}
+	priceURL := fmt.Sprintf("%s?ids=%s&vs_currencies=usd", dataSource, token)
+
+	resp, err := http.Get(priceURL)
+	if err != nil {
+		return Quote{}, fmt.Errorf("coingecko: failed to fetch price data: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Quote{}, fmt.Errorf("coingecko: unexpected status %s", resp.Status)
+	}
+
+	var priceData CoinGeckoPriceResponse
+	if err := json.NewDecoder(resp.Body).Decode(&priceData); err != nil {
+		return Quote{}, fmt.Errorf("coingecko: failed to decode price data: %w", err)
+	}
+
+	priceFloat := priceData[token].Usd
+	if priceFloat == 0 {
+		return Quote{}, fmt.Errorf("coingecko: price for token '%s' not found", token)
+	}
+
+	return Quote{
+		Source:    s.Name(),
+		Token:     token,
+		Price:     priceFloat,
+		Timestamp: time.Now(),
+	}, nil
+}
+
+// BinanceSource fetches spot prices from Binance's public ticker endpoint.
+type BinanceSource struct {
+	// HTTPClient is used for all requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+func (s *BinanceSource) Name() string { return "binance" }
+
+func (s *BinanceSource) FetchQuote(token string) (Quote, error) {
+	url := fmt.Sprintf("https://api.binance.com/api/v3/ticker/price?symbol=%sUSDT", token)
+	resp, err := s.client().Get(url)
+	if err != nil {
+		return Quote{}, fmt.Errorf("binance: failed to fetch price data: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Quote{}, fmt.Errorf("binance: unexpected status %s", resp.Status)
+	}
+
+	var body struct {
+		Price string `json:"price"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Quote{}, fmt.Errorf("binance: failed to decode price data: %w", err)
+	}
+
+	var priceFloat float64
+	if _, err := fmt.Sscanf(body.Price, "%f", &priceFloat); err != nil || priceFloat == 0 {
+		return Quote{}, fmt.Errorf("binance: price for token '%s' not found", token)
+	}
+
+	return Quote{Source: s.Name(), Token: token, Price: priceFloat, Timestamp: time.Now()}, nil
+}
+
+func (s *BinanceSource) client() *http.Client {
+	if s.HTTPClient != nil {
+		return s.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// CoinbaseSource fetches spot prices from Coinbase's public exchange rate endpoint.
+type CoinbaseSource struct {
+	// HTTPClient is used for all requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+func (s *CoinbaseSource) Name() string { return "coinbase" }
+
+func (s *CoinbaseSource) FetchQuote(token string) (Quote, error) {
+	url := fmt.Sprintf("https://api.coinbase.com/v2/prices/%s-USD/spot", token)
+	resp, err := s.client().Get(url)
+	if err != nil {
+		return Quote{}, fmt.Errorf("coinbase: failed to fetch price data: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Quote{}, fmt.Errorf("coinbase: unexpected status %s", resp.Status)
+	}
+
+	var body struct {
+		Data struct {
+			Amount string `json:"amount"`
+		} `json:"data"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Quote{}, fmt.Errorf("coinbase: failed to decode price data: %w", err)
+	}
+
+	var priceFloat float64
+	if _, err := fmt.Sscanf(body.Data.Amount, "%f", &priceFloat); err != nil || priceFloat == 0 {
+		return Quote{}, fmt.Errorf("coinbase: price for token '%s' not found", token)
+	}
+
+	return Quote{Source: s.Name(), Token: token, Price: priceFloat, Timestamp: time.Now()}, nil
+}
+
+func (s *CoinbaseSource) client() *http.Client {
+	if s.HTTPClient != nil {
+		return s.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// KrakenSource fetches spot prices from Kraken's public ticker endpoint.
+type KrakenSource struct {
+	// HTTPClient is used for all requests. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+func (s *KrakenSource) Name() string { return "kraken" }
+
+func (s *KrakenSource) FetchQuote(token string) (Quote, error) {
+	pair := token + "USD"
+	url := fmt.Sprintf("https://api.kraken.com/0/public/Ticker?pair=%s", pair)
+	resp, err := s.client().Get(url)
+	if err != nil {
+		return Quote{}, fmt.Errorf("kraken: failed to fetch price data: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return Quote{}, fmt.Errorf("kraken: unexpected status %s", resp.Status)
+	}
+
+	var body struct {
+		Result map[string]struct {
+			C []string `json:"c"` // last trade closed [price, lot volume]
+		} `json:"result"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return Quote{}, fmt.Errorf("kraken: failed to decode price data: %w", err)
+	}
+
+	for _, v := range body.Result {
+		if len(v.C) == 0 {
+			continue
+		}
+		var priceFloat float64
+		if _, err := fmt.Sscanf(v.C[0], "%f", &priceFloat); err == nil && priceFloat != 0 {
+			return Quote{Source: s.Name(), Token: token, Price: priceFloat, Timestamp: time.Now()}, nil
+		}
+	}
+	return Quote{}, fmt.Errorf("kraken: price for token '%s' not found", token)
+}
+
+func (s *KrakenSource) client() *http.Client {
+	if s.HTTPClient != nil {
+		return s.HTTPClient
+	}
+	return http.DefaultClient
+}
+
+// ChainlinkSource reads a price directly off an on-chain Chainlink aggregator
+// via latestRoundData. Callers supply their own ETH JSON-RPC backed RoundReader
+// so this package does not need to depend on a specific chain client.
+type ChainlinkSource struct {
+	// AggregatorAddress is the Chainlink price feed contract for the token.
+	AggregatorAddress string
+	// Reader performs the actual latestRoundData call against the chain.
+	Reader ChainlinkRoundReader
+}
+
+// ChainlinkRoundReader abstracts the on-chain call so this SDK does not need to
+// depend on a concrete Ethereum client implementation.
+type ChainlinkRoundReader interface {
+	LatestRoundData(aggregatorAddress string) (answer float64, updatedAt time.Time, err error)
+}
+
+func (s *ChainlinkSource) Name() string { return "chainlink" }
+
+func (s *ChainlinkSource) FetchQuote(token string) (Quote, error) {
+	if s.Reader == nil {
+		return Quote{}, fmt.Errorf("chainlink: no RoundReader configured")
+	}
+	answer, updatedAt, err := s.Reader.LatestRoundData(s.AggregatorAddress)
+	if err != nil {
+		return Quote{}, fmt.Errorf("chainlink: failed to read latestRoundData: %w", err)
+	}
+	if answer == 0 {
+		return Quote{}, fmt.Errorf("chainlink: price for token '%s' not found", token)
+	}
+	return Quote{Source: s.Name(), Token: token, Price: answer, Timestamp: updatedAt}, nil
+}
+
+// CustomHTTPSource fetches a quote from an arbitrary HTTP endpoint and extracts
+// the price with a caller-supplied parser, for data sources that don't match
+// any of the built-in adapters.
+type CustomHTTPSource struct {
+	SourceName string
+	URL        func(token string) string
+	Parse      func(body []byte) (price float64, err error)
+	httpClient *http.Client
+}
+
+func (s *CustomHTTPSource) Name() string {
+	if s.SourceName != "" {
+		return s.SourceName
+	}
+	return "custom"
+}
+
+func (s *CustomHTTPSource) FetchQuote(token string) (Quote, error) {
+	client := s.httpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+	resp, err := client.Get(s.URL(token))
+	if err != nil {
+		return Quote{}, fmt.Errorf("%s: failed to fetch price data: %w", s.Name(), err)
+	}
+	defer resp.Body.Close()
+
+	buf, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return Quote{}, fmt.Errorf("%s: failed to read response body: %w", s.Name(), err)
+	}
+
+	priceFloat, err := s.Parse(buf)
+	if err != nil {
+		return Quote{}, fmt.Errorf("%s: failed to parse price data: %w", s.Name(), err)
+	}
+	if priceFloat == 0 {
+		return Quote{}, fmt.Errorf("%s: price for token '%s' not found", s.Name(), token)
+	}
+
+	return Quote{Source: s.Name(), Token: token, Price: priceFloat, Timestamp: time.Now()}, nil
+}
@@ -0,0 +1,62 @@
+package go_arkham_oracle_sdk
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+)
+
+func TestDefaultCoinGeckoSourceFetchQuote(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"ethereum":{"usd":1234.5}}`))
+	}))
+	defer srv.Close()
+
+	s := &defaultCoinGeckoSource{dataSourceURL: srv.URL}
+	quote, err := s.FetchQuote("ethereum")
+	if err != nil {
+		t.Fatalf("FetchQuote: %v", err)
+	}
+	if quote.Price != 1234.5 {
+		t.Fatalf("Price = %v, want 1234.5", quote.Price)
+	}
+}
+
+func TestDefaultCoinGeckoSourceRejectsNon200(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer srv.Close()
+
+	s := &defaultCoinGeckoSource{dataSourceURL: srv.URL}
+	if _, err := s.FetchQuote("ethereum"); err == nil {
+		t.Fatalf("FetchQuote() with a 500 upstream response should have errored")
+	}
+}
+
+func TestCustomHTTPSourceFetchQuote(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte("42.5"))
+	}))
+	defer srv.Close()
+
+	s := &CustomHTTPSource{
+		SourceName: "myfeed",
+		URL:        func(token string) string { return srv.URL + "?token=" + token },
+		Parse: func(body []byte) (float64, error) {
+			return strconv.ParseFloat(string(body), 64)
+		},
+	}
+	quote, err := s.FetchQuote("ETH")
+	if err != nil {
+		t.Fatalf("FetchQuote: %v", err)
+	}
+	if quote.Price != 42.5 {
+		t.Fatalf("Price = %v, want 42.5", quote.Price)
+	}
+	if quote.Source != "myfeed" {
+		t.Fatalf("Source = %q, want myfeed", quote.Source)
+	}
+}
@@ -5,7 +5,28 @@ package go_arkham_oracle_sdk
 type SignedPriceData struct {
 	Price     uint64 `json:"price,string"`
 	Timestamp int64  `json:"timestamp,string"`
-	Signature []byte `json:"signature"`
+	// Method identifies the aggregation strategy used to combine sources (e.g.
+	// "median", "vwap", "twap"). Included, with SourceCount, in the signed
+	// pre-image under SchemeEd25519Keccak so downstream verifiers can enforce
+	// provenance rules; the SchemeEVMSecp256k1 digest covers neither field.
+	Method string `json:"method"`
+	// SourceCount is the number of independent sources that survived outlier
+	// rejection and contributed to Price.
+	SourceCount int `json:"sourceCount,string"`
+	// Scheme identifies the signing scheme used to produce Signature, e.g.
+	// "ed25519-keccak256" or "evm-secp256k1-eip712". Empty is treated as
+	// SchemeEd25519Keccak for backward compatibility.
+	Scheme string `json:"scheme,omitempty"`
+	// TokenAddress, ChainID, and VerifyingContract are populated only under
+	// SchemeEVMSecp256k1, and are required to reconstruct the EIP-712 digest.
+	TokenAddress      string `json:"tokenAddress,omitempty"`
+	ChainID           string `json:"chainId,omitempty"`
+	VerifyingContract string `json:"verifyingContract,omitempty"`
+	// KeyID identifies the rotating key that produced Signature under
+	// SchemeEd25519Keccak. KeyExpiresAt is its expiry in RFC3339, if any.
+	KeyID        string `json:"keyId,omitempty"`
+	KeyExpiresAt string `json:"keyExpiresAt,omitempty"`
+	Signature    []byte `json:"signature"`
 }
 
 // CoinGeckoPriceResponse is an internal struct for JSON unmarshalling of CoinGecko-like price data.